@@ -27,6 +27,194 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// WorkloadReference identifies the workload for which a ZoneAwareUpdate applies to.
+type WorkloadReference struct {
+	// Kind of the workload.
+	// +kubebuilder:validation:Enum=StatefulSet;Deployment;DaemonSet
+	Kind string `json:"kind"`
+
+	// Name of the workload, in the same namespace as the ZoneAwareUpdate.
+	Name string `json:"name"`
+}
+
+// ZoneOrderStrategy defines how zones are ordered during a rollout.
+// +kubebuilder:validation:Enum=Alphabetical;LeastLoadedFirst;Rotating
+type ZoneOrderStrategy string
+
+const (
+	// AlphabeticalZoneOrder updates zones in alphabetical order.
+	AlphabeticalZoneOrder ZoneOrderStrategy = "Alphabetical"
+
+	// LeastLoadedFirstZoneOrder updates first the zones with fewer pods, so a bad revision impacts
+	// as few pods as possible before being detected.
+	LeastLoadedFirstZoneOrder ZoneOrderStrategy = "LeastLoadedFirst"
+
+	// RotatingZoneOrder starts each new revision from the zone following the one updated first
+	// in the previous revision, so the same zone doesn't always take the risk first.
+	RotatingZoneOrder ZoneOrderStrategy = "Rotating"
+)
+
+// ZoneAwareUpdatePhase is a label for the state of a rollout.
+type ZoneAwareUpdatePhase string
+
+const (
+	// ZoneAwareUpdateProgressing means pods in an old revision are being updated.
+	ZoneAwareUpdateProgressing ZoneAwareUpdatePhase = "Progressing"
+
+	// ZoneAwareUpdateAwaitingApproval means the rollout is waiting for a manual approval to update the PendingZone.
+	ZoneAwareUpdateAwaitingApproval ZoneAwareUpdatePhase = "AwaitingApproval"
+
+	// ZoneAwareUpdateRollingBack means pods in the revision rolled back are being replaced.
+	ZoneAwareUpdateRollingBack ZoneAwareUpdatePhase = "RollingBack"
+
+	// ZoneAwareUpdateCanary means a canary pod is being updated in each zone, before the zones are updated one by one.
+	ZoneAwareUpdateCanary ZoneAwareUpdatePhase = "Canary"
+
+	// ZoneAwareUpdatePaused means the rollout is paused, see PauseReason.
+	ZoneAwareUpdatePaused ZoneAwareUpdatePhase = "Paused"
+
+	// ZoneAwareUpdateCompleted means all pods are in the update revision.
+	ZoneAwareUpdateCompleted ZoneAwareUpdatePhase = "Completed"
+)
+
+// PauseReason is the cause of a paused rollout.
+type PauseReason string
+
+const (
+	// PauseReasonAlarm means the rollout was paused because a pause alarm is in alarm, see PausingAlarms.
+	PauseReasonAlarm PauseReason = "Alarm"
+
+	// PauseReasonManual means the rollout was paused through the Paused spec field.
+	PauseReasonManual PauseReason = "Manual"
+
+	// PauseReasonHealthGate means the rollout was paused because the HealthGate returned 409 Conflict.
+	PauseReasonHealthGate PauseReason = "HealthGate"
+
+	// PauseReasonAlarmFailure means the rollout was paused because the state of the pause alarms couldn't be
+	// retrieved, see the AlarmsUnavailable condition.
+	PauseReasonAlarmFailure PauseReason = "AlarmFailure"
+)
+
+const (
+	// ZoneAwareUpdateConditionProgressing is true while pods are being updated or rolled back.
+	ZoneAwareUpdateConditionProgressing = "Progressing"
+
+	// ZoneAwareUpdateConditionAvailable is true when all pods are in the update revision.
+	ZoneAwareUpdateConditionAvailable = "Available"
+
+	// ZoneAwareUpdateConditionPaused is true when the rollout is paused, with the PauseReason as reason.
+	ZoneAwareUpdateConditionPaused = "Paused"
+
+	// ZoneAwareUpdateConditionDegraded is true when the rollout failed or was rolled back.
+	ZoneAwareUpdateConditionDegraded = "Degraded"
+
+	// ZoneAwareUpdateConditionFailed is true when updated pods didn't become ready within the ProgressDeadlineSeconds,
+	// or the HealthGate failed with the Fail policy.
+	ZoneAwareUpdateConditionFailed = "Failed"
+
+	// ProgressDeadlineExceededReason is the reason of the Failed condition when the ProgressDeadlineSeconds is exceeded.
+	ProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+	// ZoneAwareUpdateConditionAlarmsUnavailable is true while the state of the pause alarms can't be retrieved,
+	// with the backend error as message.
+	ZoneAwareUpdateConditionAlarmsUnavailable = "AlarmsUnavailable"
+
+	// HealthGateFailedReason is the reason of the Failed condition when the HealthGate fails with the Fail policy.
+	HealthGateFailedReason = "HealthGateFailed"
+)
+
+// AlarmFailurePolicy defines how the rollout behaves when the state of the pause alarms can't be retrieved.
+// +kubebuilder:validation:Enum=Pause;Proceed
+type AlarmFailurePolicy string
+
+const (
+	// AlarmFailurePause pauses the rollout until the state of the pause alarms can be retrieved.
+	AlarmFailurePause AlarmFailurePolicy = "Pause"
+
+	// AlarmFailureProceed proceeds with the rollout as if the pause alarms were not in alarm,
+	// up to the MaxAlarmOutageDuration.
+	AlarmFailureProceed AlarmFailurePolicy = "Proceed"
+)
+
+// DeletionMethod defines how the pods in the old revision are deleted.
+// +kubebuilder:validation:Enum=Delete;Evict
+type DeletionMethod string
+
+const (
+	// DeletionMethodDelete deletes the pods directly.
+	DeletionMethodDelete DeletionMethod = "Delete"
+
+	// DeletionMethodEvict deletes the pods through the Eviction API, so PodDisruptionBudgets and
+	// ZoneDisruptionBudgets are honored.
+	DeletionMethodEvict DeletionMethod = "Evict"
+)
+
+// HealthGateFailurePolicy defines how the failures of a HealthGate are handled.
+// +kubebuilder:validation:Enum=Retry;Fail
+type HealthGateFailurePolicy string
+
+const (
+	// HealthGateRetry retries the HealthGate with exponential backoff.
+	HealthGateRetry HealthGateFailurePolicy = "Retry"
+
+	// HealthGateFail sets the Failed condition, and rolls back the workload if RollbackOnFailure is set.
+	HealthGateFail HealthGateFailurePolicy = "Fail"
+)
+
+// HealthGate is an HTTP endpoint called before each step of a rollout.
+type HealthGate struct {
+	// URL of the endpoint. Before each step, a POST request is sent with the namespace and name of the
+	// ZoneAwareUpdate, and the zone, step and revision to be updated, in JSON. 2xx responses proceed with
+	// the step, and 409 Conflict pauses the rollout.
+	URL string `json:"url"`
+
+	// Timeout of the requests. Default value is 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Policy for other responses (e.g. 5xx) and failed requests. Default value is Retry.
+	//+kubebuilder:default:="Retry"
+	// +optional
+	FailurePolicy HealthGateFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// Canary defines the canary phase of a rollout.
+type Canary struct {
+	// Time to wait after the canary pods are updated and ready in all zones, before updating the zones
+	// one by one. The PauseRolloutAlarm is still checked while waiting. No wait by default.
+	// +optional
+	BakeDuration *metav1.Duration `json:"bakeDuration,omitempty"`
+}
+
+// BlockedPod is a pod whose eviction was blocked by a disruption budget.
+type BlockedPod struct {
+	// Name of the pod.
+	Name string `json:"name"`
+
+	// Budget blocking the eviction, e.g. PodDisruptionBudget <name>. Empty if it's unknown.
+	// +optional
+	Budget string `json:"budget,omitempty"`
+
+	// Message returned by the Eviction API.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Number of consecutive blocked evictions of the pod.
+	Attempts int32 `json:"attempts"`
+}
+
+// RollbackStatus describes a rollback triggered by the PauseRolloutAlarm or a failed rollout.
+type RollbackStatus struct {
+	// Revision that was being rolled out when the rollback was triggered.
+	FromRevision string `json:"fromRevision"`
+
+	// Revision restored by the rollback.
+	ToRevision string `json:"toRevision"`
+
+	// Time when the rollback was triggered.
+	Time metav1.Time `json:"time"`
+}
+
 // ZoneAwareUpdateSpec defines the desired state of ZoneAwareUpdate
 type ZoneAwareUpdateSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -35,6 +223,23 @@ type ZoneAwareUpdateSpec struct {
 	// The name of the StatefulSet for which the ZoneAwareUpdate applies to.
 	StatefulSet string `json:"statefulset,omitempty"`
 
+	// Names of multiple StatefulSets rolled out together. Zone ordering, update steps and
+	// OldReplicas apply across all of them, so a zone is fully updated in every StatefulSet
+	// before moving to the next zone.
+	// +optional
+	StatefulSets []string `json:"statefulsets,omitempty"`
+
+	// Label selector for StatefulSets rolled out together, in addition to the ones listed in StatefulSets.
+	// +optional
+	StatefulSetSelector *metav1.LabelSelector `json:"statefulsetSelector,omitempty"`
+
+	// The workload (kind and name) for which the ZoneAwareUpdate applies to.
+	// When set, it takes precedence over the StatefulSet field.
+	// Deployments must be paused, so their rollout is driven by the ZoneAwareUpdate
+	// instead of the native RollingUpdate strategy. DaemonSets must use the OnDelete update strategy.
+	// +optional
+	Workload *WorkloadReference `json:"workload,omitempty"`
+
 	// Max number (or %) of pods that can be updated at the same time.
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 
@@ -44,12 +249,132 @@ type ZoneAwareUpdateSpec struct {
 	//+kubebuilder:default:="2.0"
 	ExponentialFactor string `json:"exponentialFactor,omitempty"`
 
-	// CW alarm name used to pause/skip updates.
+	// Number (or %) of pods updated at each step in a zone, e.g. [1, 5, 25%, 100%]. Percentages are of the
+	// workload replicas, like MaxUnavailable. The last step is repeated until the zone is updated, and every
+	// step is capped by MaxUnavailable. When set, the ExponentialFactor is ignored.
+	// +optional
+	Steps []intstr.IntOrString `json:"steps,omitempty"`
+
+	// Canary phase, updating a single pod in each zone before the zones are updated one by one, for
+	// StatefulSets and DaemonSets. The canary pods are updated up to MaxUnavailable zones at a time, once
+	// all pods are ready and the pause alarms and the HealthGate of the canary zones allow it. Skipped with
+	// DryRun. No canary phase by default.
+	// +optional
+	Canary *Canary `json:"canary,omitempty"`
+
+	// Max number of zones updated at the same time, taken in the zone order. The next zones start once all
+	// the zones being updated are updated. The pods deleted at each step across all the zones never exceed
+	// MaxUnavailable, and the rollout stops while there are unhealthy pods outside of them. Default value is 1.
+	//+kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentZones int32 `json:"maxConcurrentZones,omitempty"`
+
+	// Zones in the order they should be updated. Zones with pods that are not listed here
+	// are updated afterwards, ordered by the ZoneOrderStrategy.
+	// +optional
+	ZoneOrder []string `json:"zoneOrder,omitempty"`
+
+	// Strategy used to order the zones. Default value is Alphabetical.
+	// The order is computed when a new revision is detected and kept until the rollout completes.
+	//+kubebuilder:default:="Alphabetical"
+	// +optional
+	ZoneOrderStrategy ZoneOrderStrategy `json:"zoneOrderStrategy,omitempty"`
+
+	// Minimum time the pods updated in a step should be ready before the next step starts.
+	// The workload's minReadySeconds is also honored, so pods only count as updated
+	// once they have been ready for the longest of both.
+	// +optional
+	MinStepInterval *metav1.Duration `json:"minStepInterval,omitempty"`
+
+	// Time to wait after all pods in a zone are updated and ready before updating the next zone.
+	// The PauseRolloutAlarm is still checked while waiting. No wait by default.
+	// +optional
+	ZoneBakeDuration *metav1.Duration `json:"zoneBakeDuration,omitempty"`
+
+	// Require a manual approval before updating each zone after the first one.
+	// Zones are approved by adding them to the ApprovedZones status field.
+	// +optional
+	RequireZoneApproval bool `json:"requireZoneApproval,omitempty"`
+
+	// Require a manual approval once this number of pods is updated, before updating the remaining pods.
+	// The rollout proceeds once any zone, usually the zone being updated, is added to the ApprovedZones
+	// status field for the update revision.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ApprovalAfterPods *int32 `json:"approvalAfterPods,omitempty"`
+
+	// Flag to pause the rollout (default false). No pods are deleted while the rollout is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Maximum time in seconds for updated pods to become ready. Once exceeded, the ZoneAwareUpdate gets
+	// the Failed condition naming the pods and zones not ready. No deadline by default.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Flag to roll back the workload when the ProgressDeadlineSeconds is exceeded, or the HealthGate
+	// fails with the Fail policy (default false).
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// CW alarm name used to pause/skip updates, either a composite or a metric alarm.
 	// Alarm should be on the same account and region.
 	// +optional
 	PauseRolloutAlarm string `json:"pauseRolloutAlarm,omitempty"`
 
-	// Flag to ignore the PauseRolloutAlarm (default false)
+	// CW alarm names used to pause/skip updates, along with the PauseRolloutAlarm.
+	// The rollout is paused while any of them is in alarm.
+	// +optional
+	PauseRolloutAlarms []string `json:"pauseRolloutAlarms,omitempty"`
+
+	// CW alarm names per zone, checked along with the PauseRolloutAlarm and PauseRolloutAlarms
+	// while the zone is updated. The {zone} placeholder can also be used in the PauseRolloutAlarm,
+	// PauseRolloutAlarms and PauseRolloutQuery to check zonal alarms.
+	// +optional
+	ZonePauseRolloutAlarms map[string]string `json:"zonePauseRolloutAlarms,omitempty"`
+
+	// Flag to also pause the rollout while an alarm is in INSUFFICIENT_DATA state,
+	// or the PauseRolloutQuery has no samples (default false).
+	// +optional
+	PauseOnInsufficientData bool `json:"pauseOnInsufficientData,omitempty"`
+
+	// Prometheus query used to pause/skip updates while it's breaching, checked along with the PauseRolloutAlarm.
+	// Requires the controller to be started with the --prometheus-address flag.
+	// +optional
+	PauseRolloutQuery *PrometheusQuery `json:"pauseRolloutQuery,omitempty"`
+
+	// Flag to roll back the workload when the PauseRolloutAlarm is in alarm, instead of pausing the rollout
+	// (default false). The pod template of the CurrentRevision is restored, and the pods already updated
+	// are replaced, most recently updated first. The alarm is ignored while rolling back.
+	// +optional
+	RollbackOnAlarm bool `json:"rollbackOnAlarm,omitempty"`
+
+	// HTTP endpoint called before each step, which can proceed with the step or pause the rollout.
+	// It's not called while rolling back.
+	// +optional
+	HealthGate *HealthGate `json:"healthGate,omitempty"`
+
+	// Behavior of the rollout when the state of the pause alarms can't be retrieved: Pause or Proceed.
+	// The AlarmsUnavailable condition names the backend error in both cases. Default value is Pause.
+	//+kubebuilder:default:="Pause"
+	// +optional
+	AlarmFailurePolicy AlarmFailurePolicy `json:"alarmFailurePolicy,omitempty"`
+
+	// Maximum time the rollout proceeds while the state of the pause alarms can't be retrieved, with the
+	// Proceed AlarmFailurePolicy. The rollout is paused once exceeded. No limit by default.
+	// +optional
+	MaxAlarmOutageDuration *metav1.Duration `json:"maxAlarmOutageDuration,omitempty"`
+
+	// Method used to delete the pods in the old revision of StatefulSets and DaemonSets: Delete or Evict.
+	// With Evict, pods are deleted through the Eviction API, and the rollout is retried with an exponential
+	// backoff while evictions are blocked by a disruption budget. Default value is Delete.
+	//+kubebuilder:default:="Delete"
+	// +optional
+	DeletionMethod DeletionMethod `json:"deletionMethod,omitempty"`
+
+	// Flag to ignore the PauseRolloutAlarm and PauseRolloutQuery (default false)
 	// +optional
 	IgnoreAlarm bool `json:"ignoreAlarm,omitempty"`
 
@@ -58,16 +383,31 @@ type ZoneAwareUpdateSpec struct {
 	DryRun bool `json:"dryRun,omitempty"`
 }
 
+// PrometheusQuery is a PromQL query compared with a threshold.
+type PrometheusQuery struct {
+	// PromQL query, evaluated with the Prometheus instant query API.
+	// The query must return a scalar or an instant vector.
+	Query string `json:"query"`
+
+	// The query is breaching when any of its samples is greater than the threshold, in float string.
+	// Queries without samples are not breaching, unless PauseOnInsufficientData is set.
+	Threshold string `json:"threshold"`
+}
+
 // ZoneAwareUpdateStatus defines the observed state of ZoneAwareUpdate
 type ZoneAwareUpdateStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// CurrentRevision indicates the version of the StatefulSet used to generate Pods
+	// Most recent generation observed when updating this ZAU status.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CurrentRevision indicates the version of the workload used to generate Pods
 	// +optional
 	CurrentRevision string `json:"currentRevision,omitempty"`
 
-	// UpdateRevision indicates the new version of the StatefulSet
+	// UpdateRevision indicates the new version of the workload
 	// +optional
 	UpdateRevision string `json:"updateRevision,omitempty"`
 
@@ -85,9 +425,93 @@ type ZoneAwareUpdateStatus struct {
 	// +optional
 	DeletedReplicas int32 `json:"deletedReplicas,omitempty"`
 
-	// PausedRollout indicates if the rollout was paused becaused the PauseRolloutAlarm is in alarm.
+	// StepIndex is the index in the Steps of the last step in the UpdatingZone, when Steps are set.
+	// +optional
+	StepIndex int32 `json:"stepIndex,omitempty"`
+
+	// CanaryRevision is the revision whose canary phase completed.
+	// +optional
+	CanaryRevision string `json:"canaryRevision,omitempty"`
+
+	// CanaryBakeEndTime is the time when the canary pods are baked, and the zones can start to be updated.
+	// +optional
+	CanaryBakeEndTime *metav1.Time `json:"canaryBakeEndTime,omitempty"`
+
+	// PlannedStepSize is the number of pods planned to be updated in the last step in each of the
+	// UpdatingZones, capped by MaxUnavailable. Fewer pods are updated when fewer pods are left in the zone.
+	// +optional
+	PlannedStepSize int32 `json:"plannedStepSize,omitempty"`
+
+	// ZoneOrder is the order in which zones are updated for the UpdateRevision.
+	// +optional
+	ZoneOrder []string `json:"zoneOrder,omitempty"`
+
+	// Phase of the rollout: Canary, Progressing, AwaitingApproval, RollingBack, Paused or Completed.
+	// +optional
+	Phase ZoneAwareUpdatePhase `json:"phase,omitempty"`
+
+	// PendingZone is the zone waiting for a manual approval to be updated.
+	// +optional
+	PendingZone string `json:"pendingZone,omitempty"`
+
+	// ApprovedZones are the zones manually approved to be updated in the UpdateRevision.
+	// It's reset when a new revision is detected.
+	// +optional
+	ApprovedZones []string `json:"approvedZones,omitempty"`
+
+	// Rollback is set when the rollout was rolled back because the PauseRolloutAlarm went into alarm,
+	// or the ProgressDeadlineSeconds was exceeded. It's reset when a new revision is rolled out.
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+
+	// UpdatingZone is the zone where pods are being updated.
+	// It's the first of the UpdatingZones when MaxConcurrentZones is greater than 1.
+	// +optional
+	UpdatingZone string `json:"updatingZone,omitempty"`
+
+	// UpdatingZones are the zones where pods are being updated, up to MaxConcurrentZones.
+	// +optional
+	UpdatingZones []string `json:"updatingZones,omitempty"`
+
+	// NextZoneEligibleTime is the time when the next zone can start to be updated,
+	// after the UpdatingZone bakes for the ZoneBakeDuration.
+	// +optional
+	NextZoneEligibleTime *metav1.Time `json:"nextZoneEligibleTime,omitempty"`
+
+	// PausedRollout indicates if the rollout was paused becaused a pause alarm is in alarm,
+	// or the Paused spec field is set. See PausingAlarms for the alarms pausing the rollout.
 	// +optional
 	PausedRollout bool `json:"pausedRollout,omitempty"`
+
+	// PauseReason is the cause of the paused rollout: Alarm, Manual, HealthGate or AlarmFailure.
+	// +optional
+	PauseReason PauseReason `json:"pauseReason,omitempty"`
+
+	// PausedTime is the time when the rollout was paused.
+	// +optional
+	PausedTime *metav1.Time `json:"pausedTime,omitempty"`
+
+	// ResumedTime is the time when the rollout was last resumed after a manual pause. The progress deadline
+	// of updated pods that were not ready before counts from it.
+	// +optional
+	ResumedTime *metav1.Time `json:"resumedTime,omitempty"`
+
+	// PausingAlarms are the alarms pausing the rollout when the PauseReason is Alarm.
+	// A breaching PauseRolloutQuery is reported as PauseRolloutQuery.
+	// +optional
+	PausingAlarms []string `json:"pausingAlarms,omitempty"`
+
+	// BlockedPods are the pods whose eviction was blocked in the last step, with the Evict DeletionMethod.
+	// +optional
+	BlockedPods []BlockedPod `json:"blockedPods,omitempty"`
+
+	// Conditions of the rollout: Progressing, Available, Paused, Degraded, Failed and AlarmsUnavailable.
+	// The Failed condition is set when the ProgressDeadlineSeconds is exceeded, and kept while
+	// rolling back, until a new revision is rolled out.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
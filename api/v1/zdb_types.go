@@ -31,6 +31,17 @@ import (
 // ZoneDisruptionBudgets CRD was based on the PDB resource definition:
 // https://github.com/kubernetes/kubernetes/blob/05701a1309ae9f248b358bc98795605821e54b62/pkg/apis/policy/types.go#L25-L84
 
+const (
+	// ZoneDisruptionBudgetConditionReady is true when the ZDB status was computed from the selected pods.
+	ZoneDisruptionBudgetConditionReady = "Ready"
+
+	// ZoneDisruptionBudgetConditionInsufficientPods is true when a zone has fewer healthy pods than desired.
+	ZoneDisruptionBudgetConditionInsufficientPods = "InsufficientPods"
+
+	// ZoneDisruptionBudgetConditionBlocked is true when no disruptions are allowed in any zone.
+	ZoneDisruptionBudgetConditionBlocked = "Blocked"
+)
+
 // ZoneDisruptionBudgetSpec defines the desired state of ZoneDisruptionBudget
 type ZoneDisruptionBudgetSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -83,6 +94,12 @@ type ZoneDisruptionBudgetStatus struct {
 	DesiredHealthy map[string]int32 `json:"desiredHealthy,omitempty"`
 	// Total number of expected replicas per zone
 	ExpectedPods map[string]int32 `json:"expectedPods,omitempty"`
+
+	// Conditions of the budget: Ready, InsufficientPods and Blocked.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
@@ -30,6 +30,107 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockedPod) DeepCopyInto(out *BlockedPod) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockedPod.
+func (in *BlockedPod) DeepCopy() *BlockedPod {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockedPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Canary) DeepCopyInto(out *Canary) {
+	*out = *in
+	if in.BakeDuration != nil {
+		in, out := &in.BakeDuration, &out.BakeDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Canary.
+func (in *Canary) DeepCopy() *Canary {
+	if in == nil {
+		return nil
+	}
+	out := new(Canary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthGate) DeepCopyInto(out *HealthGate) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthGate.
+func (in *HealthGate) DeepCopy() *HealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQuery) DeepCopyInto(out *PrometheusQuery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQuery.
+func (in *PrometheusQuery) DeepCopy() *PrometheusQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneAwareUpdate) DeepCopyInto(out *ZoneAwareUpdate) {
 	*out = *in
@@ -92,11 +193,88 @@ func (in *ZoneAwareUpdateList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneAwareUpdateSpec) DeepCopyInto(out *ZoneAwareUpdateSpec) {
 	*out = *in
+	if in.StatefulSets != nil {
+		in, out := &in.StatefulSets, &out.StatefulSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatefulSetSelector != nil {
+		in, out := &in.StatefulSetSelector, &out.StatefulSetSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadReference)
+		**out = **in
+	}
 	if in.MaxUnavailable != nil {
 		in, out := &in.MaxUnavailable, &out.MaxUnavailable
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]intstr.IntOrString, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(Canary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneOrder != nil {
+		in, out := &in.ZoneOrder, &out.ZoneOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinStepInterval != nil {
+		in, out := &in.MinStepInterval, &out.MinStepInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ZoneBakeDuration != nil {
+		in, out := &in.ZoneBakeDuration, &out.ZoneBakeDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ApprovalAfterPods != nil {
+		in, out := &in.ApprovalAfterPods, &out.ApprovalAfterPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PauseRolloutAlarms != nil {
+		in, out := &in.PauseRolloutAlarms, &out.PauseRolloutAlarms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZonePauseRolloutAlarms != nil {
+		in, out := &in.ZonePauseRolloutAlarms, &out.ZonePauseRolloutAlarms
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PauseRolloutQuery != nil {
+		in, out := &in.PauseRolloutQuery, &out.PauseRolloutQuery
+		*out = new(PrometheusQuery)
+		**out = **in
+	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(HealthGate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxAlarmOutageDuration != nil {
+		in, out := &in.MaxAlarmOutageDuration, &out.MaxAlarmOutageDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareUpdateSpec.
@@ -119,6 +297,59 @@ func (in *ZoneAwareUpdateStatus) DeepCopyInto(out *ZoneAwareUpdateStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.CanaryBakeEndTime != nil {
+		in, out := &in.CanaryBakeEndTime, &out.CanaryBakeEndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ZoneOrder != nil {
+		in, out := &in.ZoneOrder, &out.ZoneOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovedZones != nil {
+		in, out := &in.ApprovedZones, &out.ApprovedZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdatingZones != nil {
+		in, out := &in.UpdatingZones, &out.UpdatingZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextZoneEligibleTime != nil {
+		in, out := &in.NextZoneEligibleTime, &out.NextZoneEligibleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PausedTime != nil {
+		in, out := &in.PausedTime, &out.PausedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResumedTime != nil {
+		in, out := &in.ResumedTime, &out.ResumedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PausingAlarms != nil {
+		in, out := &in.PausingAlarms, &out.PausingAlarms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockedPods != nil {
+		in, out := &in.BlockedPods, &out.BlockedPods
+		*out = make([]BlockedPod, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareUpdateStatus.
@@ -260,6 +491,13 @@ func (in *ZoneDisruptionBudgetStatus) DeepCopyInto(out *ZoneDisruptionBudgetStat
 			(*out)[key] = val
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneDisruptionBudgetStatus.
@@ -101,6 +101,7 @@ var _ = BeforeSuite(func() {
 		Scheme:        k8sManager.GetScheme(),
 		Logger:        ctrl.Log.WithName("zdb-controller"),
 		PodZoneHelper: &podZoneHelper,
+		Recorder:      k8sManager.GetEventRecorderFor("zdb-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
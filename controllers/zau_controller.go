@@ -21,8 +21,10 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,14 +33,19 @@ import (
 	"github.com/go-logr/logr"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/integer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -55,6 +62,21 @@ import (
 
 const requeueInterval = 10 * time.Second
 
+const (
+	// Backoff to retry pod evictions blocked by a disruption budget
+	minEvictionBackoff = 5 * time.Second
+	maxEvictionBackoff = 5 * time.Minute
+)
+
+// Finalizer used to restore the update strategy of the StatefulSets switched to OnDelete
+// by the StatefulSet mutating webhook, when the ZAU is deleted.
+const restoreUpdateStrategyFinalizer = "zonecontrol.k8s.aws/restore-update-strategy"
+
+const defaultHealthGateTimeout = 10 * time.Second
+
+// pauseRolloutQueryAlarm is the name reported in the PausingAlarms status for a breaching PauseRolloutQuery.
+const pauseRolloutQueryAlarm = "PauseRolloutQuery"
+
 type CloudWatchAPI interface {
 	DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
 }
@@ -66,16 +88,26 @@ type ZoneAwareUpdateReconciler struct {
 	Logger             logr.Logger
 	PodZoneHelper      *podzone.Helper
 	AlarmStateProvider utils.AlarmStateProvider
+	QueryStateProvider utils.QueryStateProvider
+	HealthGateChecker  utils.HealthGateChecker
+	Recorder           record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=zonecontrol.k8s.aws,resources=zoneawareupdates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=zonecontrol.k8s.aws,resources=zoneawareupdates/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=zonecontrol.k8s.aws,resources=zoneawareupdates/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
 //+kubebuilder:rbac:groups="",resources=pods/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
-//+kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups="apps",resources=statefulsets/status,verbs=get;update
+//+kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups="apps",resources=daemonsets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="apps",resources=controllerrevisions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -91,37 +123,147 @@ func (r *ZoneAwareUpdateReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	r.Logger.Info("Begin to process ZAU", "zau", zau.Name)
 
-	var sts apps.StatefulSet
-	if err := r.Get(ctx, types.NamespacedName{Name: zau.Spec.StatefulSet, Namespace: zau.Namespace}, &sts); err != nil {
-		r.Logger.Error(err, "Unable to fetch statefulset")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+	if !zau.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeZau(ctx, &zau)
+	}
+
+	if targetsStatefulSets(&zau) && !controllerutil.ContainsFinalizer(&zau, restoreUpdateStrategyFinalizer) {
+		controllerutil.AddFinalizer(&zau, restoreUpdateStrategyFinalizer)
+		if err := r.Update(ctx, &zau); err != nil {
+			r.Logger.Error(err, "Failed to add the ZAU finalizer", "zau", zau.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	w, err := r.getWorkload(ctx, &zau)
+	if err != nil {
+		r.Logger.Error(err, "Unable to fetch workload")
+		return ctrl.Result{}, err
+	}
+	if w == nil {
+		kind, name := workloadRef(&zau)
+		r.Logger.Info("Workload not found", "kind", kind, "name", name)
+		return ctrl.Result{}, nil
+	}
+
+	if sw, ok := w.(*statefulSetWorkload); ok && !zau.Spec.DryRun {
+		if err := r.enforceOnDelete(ctx, &zau, sw); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	recheck, err := r.updateStatefulSet(ctx, &zau, &sts)
+	recheckTime, err := r.updateWorkload(ctx, &zau, w)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	metrics.PublishZauStatusMetrics(&zau)
 
-	if recheck {
-		return ctrl.Result{RequeueAfter: requeueInterval}, nil
+	if recheckTime != nil {
+		return ctrl.Result{RequeueAfter: time.Until(*recheckTime)}, nil
 	}
 
 	return ctrl.Result{}, nil
 }
 
-func (r *ZoneAwareUpdateReconciler) updateStatefulSet(ctx context.Context, zau *opsv1.ZoneAwareUpdate, sts *apps.StatefulSet) (bool, error) {
-	if !zau.Spec.DryRun && sts.Spec.UpdateStrategy.Type != apps.OnDeleteStatefulSetStrategyType {
-		r.Logger.Info("Statefulset update strategy is not OnDelete")
-		return false, nil
+// finalizeZau restores the original update strategy of the StatefulSets rolled out by the ZAU being deleted,
+// and removes its finalizer.
+func (r *ZoneAwareUpdateReconciler) finalizeZau(ctx context.Context, zau *opsv1.ZoneAwareUpdate) error {
+	if !controllerutil.ContainsFinalizer(zau, restoreUpdateStrategyFinalizer) {
+		return nil
+	}
+
+	// StatefulSets are never switched to OnDelete for an empty StatefulSetSelector, there is nothing to restore
+	w, err := r.getWorkload(ctx, zau)
+	if err != nil && err != errEmptyStatefulSetSelector {
+		r.Logger.Error(err, "Unable to fetch workload")
+		return err
+	}
+	if sw, ok := w.(*statefulSetWorkload); ok {
+		for _, sts := range sw.statefulSets {
+			patch := client.MergeFrom(sts.DeepCopy())
+			restored, err := utils.RestoreUpdateStrategy(sts)
+			if err != nil {
+				r.Logger.Error(err, "Unable to restore the StatefulSet update strategy", "sts", sts.Name)
+				return err
+			}
+			_, labeled := sts.Labels[utils.ZoneAwareUpdateLabel]
+			if !restored && !labeled {
+				continue
+			}
+			delete(sts.Labels, utils.ZoneAwareUpdateLabel)
+			if err := r.Patch(ctx, sts, patch); err != nil {
+				r.Logger.Error(err, "Failed to patch StatefulSet", "sts", sts.Name)
+				return err
+			}
+			r.Logger.Info("Restored StatefulSet update strategy", "sts", sts.Name, "strategy", sts.Spec.UpdateStrategy.Type)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(zau, restoreUpdateStrategyFinalizer)
+	return r.Update(ctx, zau)
+}
+
+// enforceOnDelete switches the StatefulSets rolled out by the ZAU to the OnDelete update strategy, so their pods
+// are only updated by the ZAU, and labels them so the StatefulSet webhook keeps the strategy on later updates.
+func (r *ZoneAwareUpdateReconciler) enforceOnDelete(ctx context.Context, zau *opsv1.ZoneAwareUpdate, sw *statefulSetWorkload) error {
+	for _, sts := range sw.statefulSets {
+		patch := client.MergeFrom(sts.DeepCopy())
+		changed, err := utils.ForceOnDeleteUpdateStrategy(sts)
+		if err != nil {
+			return err
+		}
+		if sts.Labels[utils.ZoneAwareUpdateLabel] != zau.Name {
+			if sts.Labels == nil {
+				sts.Labels = map[string]string{}
+			}
+			sts.Labels[utils.ZoneAwareUpdateLabel] = zau.Name
+		} else if !changed {
+			continue
+		}
+		if err := r.Patch(ctx, sts, patch); err != nil {
+			r.Logger.Error(err, "Failed to patch StatefulSet", "sts", sts.Name)
+			return err
+		}
+		if changed {
+			r.Logger.Info("Switched StatefulSet update strategy to OnDelete", "sts", sts.Name, "zau", zau.Name)
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "UpdateStrategyEnforced",
+				"Switched the update strategy of StatefulSet %s to OnDelete", sts.Name)
+		}
+	}
+	return nil
+}
+
+func (r *ZoneAwareUpdateReconciler) updateStatefulSet(ctx context.Context, zau *opsv1.ZoneAwareUpdate, sts *apps.StatefulSet) (*time.Time, error) {
+	return r.updateWorkload(ctx, zau, newStatefulSetWorkload(r.Client, sts))
+}
+
+func (r *ZoneAwareUpdateReconciler) updateWorkload(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload) (*time.Time, error) {
+	if !zau.Spec.DryRun && !w.UpdateStrategyReady() {
+		switch w.Kind() {
+		case utils.ControllerKindDeployment.Kind:
+			r.Logger.Info("Deployment is not paused or its strategy is not Recreate", "deployment", w.Name())
+		case utils.ControllerKindDS.Kind:
+			r.Logger.Info("Daemonset update strategy is not OnDelete", "daemonset", w.Name())
+		default:
+			r.Logger.Info("Statefulset update strategy is not OnDelete")
+		}
+		return nil, nil
 	}
-	pods, err := r.getStatefulSetPods(ctx, sts)
+	pods, err := w.Pods(ctx)
 	if err != nil {
-		r.Logger.Error(err, "Unable to fetch statefulset pods")
-		return false, err
+		r.Logger.Error(err, "Unable to fetch workload pods", "kind", w.Kind(), "name", w.Name())
+		return nil, err
+	}
+
+	// Updated pods are only considered available after being ready for minReadyDuration
+	minReadyDuration := time.Duration(w.MinReadySeconds()) * time.Second
+	if zau.Spec.MinStepInterval != nil && zau.Spec.MinStepInterval.Duration > minReadyDuration {
+		minReadyDuration = zau.Spec.MinStepInterval.Duration
 	}
+	var availableTime time.Time
+	updatedPods := 0
 
-	var oldPods, oldNotReadyPods []*v1.Pod
+	var oldPods, oldNotReadyPods, updatedNotReadyPods []*v1.Pod
 	oldPodsCountMap := map[string]int32{}
 	for i := range pods {
 		pod := pods[i]
@@ -130,22 +272,28 @@ func (r *ZoneAwareUpdateReconciler) updateStatefulSet(ctx context.Context, zau *
 		// completes before we continue to make progress.
 		if utils.IsTerminating(pod) {
 			r.Logger.Info("There are pods getting terminated, skipping", "pod", pod.Name)
-			return false, nil
+			return nil, nil
 		}
 
-		podRev, ok := pod.Labels[apps.ControllerRevisionHashLabelKey]
-		if !ok {
+		if _, ok := w.PodRevision(pod); !ok {
 			r.Logger.Info("Pod revision not found", "pod", pod.Name)
 			continue
 		}
 
+		updated := w.IsUpdated(pod)
+
 		// If we have updated Pod that has been created but are not running and ready we can not make progress.
-		if podRev == sts.Status.UpdateRevision && !utils.IsRunningAndReady(pod) {
-			r.Logger.Info("There are pods in the new revision that are not ready, skipping", "pod", pod.Name)
-			return false, nil
+		if updated && !utils.IsRunningAndReady(pod) {
+			updatedNotReadyPods = append(updatedNotReadyPods, pod)
+			continue
 		}
 
-		if podRev != sts.Status.UpdateRevision {
+		if updated {
+			updatedPods++
+			if podAvailableTime := utils.GetPodAvailableTime(pod, minReadyDuration); podAvailableTime.After(availableTime) {
+				availableTime = podAvailableTime
+			}
+		} else {
 			oldPods = append(oldPods, pod)
 			if !utils.IsRunningAndReady(pod) {
 				oldNotReadyPods = append(oldNotReadyPods, pod)
@@ -153,129 +301,699 @@ func (r *ZoneAwareUpdateReconciler) updateStatefulSet(ctx context.Context, zau *
 		}
 	}
 
+	if len(updatedNotReadyPods) > 0 {
+		r.Logger.Info("There are pods in the new revision that are not ready, skipping", "pod", updatedNotReadyPods[0].Name)
+
+		// A manual pause stops the progress deadline clock, which restarts when the rollout is resumed
+		if zau.Spec.Paused || zau.Status.PauseReason == opsv1.PauseReasonManual {
+			for zone, zonePods := range r.PodZoneHelper.GetZonePodsMap(ctx, oldPods) {
+				oldPodsCountMap[zone] = int32(len(zonePods))
+			}
+			zoneOrder := utils.GetZoneOrder(zau, w.UpdateRevision(), oldPodsCountMap)
+			var pauseReason opsv1.PauseReason
+			if zau.Spec.Paused {
+				r.Logger.Info("Rollout is paused, not checking the progress deadline")
+				pauseReason = opsv1.PauseReasonManual
+			}
+			if err := r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, pauseReason, nil); err != nil || zau.Spec.Paused {
+				return nil, err
+			}
+		}
+		return r.checkProgressDeadline(ctx, zau, w, updatedNotReadyPods)
+	}
+
 	if len(oldPods) == 0 {
 		r.Logger.Info("No pods to update")
-		err := r.updateStatefulSetRevision(ctx, sts)
+		err := r.completeRollout(ctx, w)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
-		return false, r.updateZauStatus(ctx, zau, sts, int32(0), int32(0), oldPodsCountMap, false)
+		for _, zone := range updatingZones(zau) {
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "ZoneCompleted", "All pods in zone %s are in revision %s",
+				zone, w.UpdateRevision())
+		}
+		if zau.Status.Phase == opsv1.ZoneAwareUpdateRollingBack {
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "RollbackCompleted", "Rolled back %s %s to revision %s",
+				w.Kind(), w.Name(), w.UpdateRevision())
+		} else if zau.Status.Phase != opsv1.ZoneAwareUpdateCompleted && zau.Status.UpdateStep > 0 {
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "RolloutCompleted", "All pods of %s %s are in revision %s",
+				w.Kind(), w.Name(), w.UpdateRevision())
+		}
+		zau.Status.UpdatingZone = ""
+		zau.Status.UpdatingZones = nil
+		zau.Status.NextZoneEligibleTime = nil
+		zau.Status.StepIndex = 0
+		zau.Status.PlannedStepSize = 0
+		return nil, r.updateZauStatus(ctx, zau, w, zau.Status.ZoneOrder, "", int32(0), int32(0), oldPodsCountMap, "", nil)
+	}
+
+	if now := time.Now(); availableTime.After(now) {
+		r.Logger.Info("Waiting for updated pods to be available", "minReadyDuration", minReadyDuration,
+			"availableTime", availableTime)
+		return &availableTime, nil
 	}
 
-	r.sortPods(oldPods)
-	r.sortPods(oldNotReadyPods)
+	// The PauseRolloutAlarm, bake time and approvals are ignored while rolling back
+	rollingBack := isRollingBack(zau, w)
+
+	r.sortPods(oldPods, rollingBack)
+	r.sortPods(oldNotReadyPods, rollingBack)
 
 	zonePodsMap := r.PodZoneHelper.GetZonePodsMap(ctx, oldPods)
-	zones := r.PodZoneHelper.GetSortedZonesFromMap(zonePodsMap)
 
 	for zone := range zonePodsMap {
 		podCount := len(zonePodsMap[zone])
 		oldPodsCountMap[zone] = int32(podCount)
 	}
 
-	firstZone := zones[0]
-	if sts.Status.Replicas != sts.Status.ReadyReplicas || len(oldNotReadyPods) > 0 {
-		notReadyMap := r.PodZoneHelper.GetZonePodsMap(ctx, oldNotReadyPods)
+	zoneOrder := utils.GetZoneOrder(zau, w.UpdateRevision(), oldPodsCountMap)
+	updateOrder := zoneOrder
+	if rollingBack {
+		// The zones updated last are rolled back first
+		updateOrder = make([]string, 0, len(zoneOrder))
+		for i := len(zoneOrder) - 1; i >= 0; i-- {
+			updateOrder = append(updateOrder, zoneOrder[i])
+		}
+	}
+	var zones []string
+	for _, zone := range updateOrder {
+		if _, ok := zonePodsMap[zone]; ok && len(zones) < maxConcurrentZones(zau) {
+			zones = append(zones, zone)
+		}
+	}
+	if len(zones) == 0 {
+		r.Logger.Info("Zone not found for pods to update, skipping")
+		return nil, nil
+	}
 
-		// Do not progress if there are unhealthy pods in multiple zones.
-		if len(notReadyMap) > 1 {
-			r.Logger.Info("There are unhealthy replicas in multiple zones, skipping")
-			return false, nil
+	// Concurrent zones are updated in batches: the next zones start once every zone of the batch is updated,
+	// so the zone bake and the step sizes apply to the whole batch.
+	if maxConcurrentZones(zau) > 1 && zau.Status.UpdateRevision == w.UpdateRevision() {
+		var batchZones []string
+		for _, zone := range updatingZones(zau) {
+			if _, ok := zonePodsMap[zone]; ok {
+				batchZones = append(batchZones, zone)
+			}
+		}
+		if len(batchZones) > 0 {
+			zones = batchZones
 		}
+	}
 
-		// If unhealthy pod is in the first zone, update it first.
-		if _, found := notReadyMap[firstZone]; found {
-			zonePodsMap = notReadyMap
-		} else {
-			// Do not progress if the unhealthy pods are not in the first zone.
-			r.Logger.Info("There are unhealthy replicas which are not in the first zone, skipping")
-			return false, nil
+	if zau.Spec.Paused {
+		r.Logger.Info("Rollout is paused", "zones", zones)
+		return nil, r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, opsv1.PauseReasonManual, nil)
+	}
+
+	if !rollingBack && inCanaryPhase(zau, w) {
+		recheckTime, completed, err := r.updateCanary(ctx, zau, w, pods, zoneOrder, zonePodsMap, oldNotReadyPods, oldPodsCountMap)
+		if err != nil || !completed {
+			return recheckTime, err
 		}
-	} else {
+	}
+
+	// Bake the previous zones before moving to the next ones
+	if updatedZones := completedZones(zau, w, zones); !rollingBack && zau.Spec.ZoneBakeDuration != nil && len(updatedZones) > 0 {
+		baking, err := r.bakeZone(ctx, zau, updatedZones)
+		if err != nil {
+			return nil, err
+		}
+		if baking {
+			alarms, pauseReason, err := r.checkZonesPauseAlarms(ctx, zau, updatedZones)
+			if err != nil {
+				return nil, err
+			}
+			if pauseReason == opsv1.PauseReasonAlarm && zau.Spec.RollbackOnAlarm && canRollback(zau, w) {
+				return nil, r.rollback(ctx, zau, w, pauseAlarmMessage(alarms))
+			}
+			r.Logger.Info("Baking updated zones before moving to the next zones", "zones", updatedZones,
+				"nextZones", zones, "nextZoneEligibleTime", zau.Status.NextZoneEligibleTime)
+			recheckTime := time.Now().Add(requeueInterval)
+			if zau.Status.NextZoneEligibleTime.Time.Before(recheckTime) {
+				recheckTime = zau.Status.NextZoneEligibleTime.Time
+			}
+			return &recheckTime, r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, pauseReason, alarms)
+		}
+	}
+
+	for _, zone := range zones {
+		if !rollingBack && r.awaitingApproval(zau, w, zones, zone, updatedPods) {
+			r.Logger.Info("Waiting for manual approval to update zone", "zone", zone, "updatedPods", updatedPods)
+			return nil, r.updateZauStatus(ctx, zau, w, zoneOrder, zone, zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, "", nil)
+		}
+	}
+
+	if !w.AllReplicasReady() || len(oldNotReadyPods) > 0 {
+		notReadyMap := r.PodZoneHelper.GetZonePodsMap(ctx, oldNotReadyPods)
+
+		// Do not progress if there are unhealthy pods outside of the zones being updated.
+		for zone := range notReadyMap {
+			if !utils.ContainsString(zones, zone) {
+				r.Logger.Info("There are unhealthy replicas outside of the zones being updated, skipping", "zone", zone)
+				return nil, nil
+			}
+		}
+		if len(notReadyMap) == 0 {
+			r.Logger.Info("There are unhealthy replicas which are not in the zones being updated, skipping")
+			return nil, nil
+		}
+
+		// Update the unhealthy pods first.
+		zonePodsMap = notReadyMap
+	} else if !rollingBack {
 		// Check PauseAlarm when all replicas are ready
-		pause, err := r.pauseRollout(ctx, zau)
+		alarms, pauseReason, err := r.checkZonesPauseAlarms(ctx, zau, zones)
 		if err != nil {
-			return false, err
+			return nil, err
+		}
+		if pauseReason == opsv1.PauseReasonAlarm && zau.Spec.RollbackOnAlarm && canRollback(zau, w) {
+			return nil, r.rollback(ctx, zau, w, pauseAlarmMessage(alarms))
+		}
+		if pauseReason != "" {
+			r.Logger.Info("Pausing rollout", "reason", pauseReason, "alarms", alarms)
+			r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, pauseReason, alarms)
+			recheckTime := time.Now().Add(requeueInterval)
+			return &recheckTime, nil
+		}
+
+		decision, err := r.checkZonesHealthGate(ctx, zau, w, zones)
+		if err != nil {
+			return nil, err
+		}
+		if decision != utils.HealthGateProceed {
+			recheckTime := time.Now().Add(requeueInterval)
+			if decision == utils.HealthGatePause {
+				return &recheckTime, r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, opsv1.PauseReasonHealthGate, nil)
+			}
+			return &recheckTime, nil
+		}
+	}
+
+	r.Logger.Info("Proceeding with zone update", "zones", zones)
+	previousZones := updatingZones(zau)
+	for _, zone := range previousZones {
+		if _, found := oldPodsCountMap[zone]; !found {
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "ZoneCompleted", "All pods in zone %s are in revision %s",
+				zone, w.UpdateRevision())
+		}
+	}
+	newZone := false
+	for _, zone := range zones {
+		if !utils.ContainsString(previousZones, zone) {
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "ZoneStarted", "Updating zone %s to revision %s", zone, w.UpdateRevision())
+			newZone = true
+		}
+	}
+	zau.Status.UpdatingZone = zones[0]
+	zau.Status.UpdatingZones = zones
+	zau.Status.NextZoneEligibleTime = nil
+	return r.deletePods(ctx, zau, w, zonePodsMap, zones, zoneOrder, oldPodsCountMap, newZone)
+}
+
+// maxConcurrentZones returns the max number of zones updated at the same time, 1 by default.
+func maxConcurrentZones(zau *opsv1.ZoneAwareUpdate) int {
+	if zau.Spec.MaxConcurrentZones < 1 {
+		return 1
+	}
+	return int(zau.Spec.MaxConcurrentZones)
+}
+
+// updatingZones returns the zones where pods were being updated in the last step.
+func updatingZones(zau *opsv1.ZoneAwareUpdate) []string {
+	if len(zau.Status.UpdatingZones) > 0 {
+		return zau.Status.UpdatingZones
+	}
+	if zau.Status.UpdatingZone != "" {
+		return []string{zau.Status.UpdatingZone}
+	}
+	return nil
+}
+
+// inCanaryPhase returns true until the canary phase of the workload UpdateRevision is completed.
+// Deployments have no canary phase, as the zone of the new pods is chosen by the scheduler. The canary
+// phase is also skipped with DryRun, as the canary pods are never updated.
+func inCanaryPhase(zau *opsv1.ZoneAwareUpdate, w workload) bool {
+	return zau.Spec.Canary != nil && !zau.Spec.DryRun && w.Kind() != utils.ControllerKindDeployment.Kind &&
+		zau.Status.CanaryRevision != w.UpdateRevision()
+}
+
+// updateCanary updates a single pod in each zone without pods in the update revision, up to MaxUnavailable
+// zones at a time. Once all zones have a canary pod, it bakes them for the canary BakeDuration and returns
+// true, so the zones can be updated one by one.
+func (r *ZoneAwareUpdateReconciler) updateCanary(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	pods []*v1.Pod, zoneOrder []string, zonePodsMap map[string][]*v1.Pod, oldNotReadyPods []*v1.Pod,
+	oldPodsCountMap map[string]int32) (*time.Time, bool, error) {
+
+	updatedPods := []*v1.Pod{}
+	for _, pod := range pods {
+		if w.IsUpdated(pod) {
+			updatedPods = append(updatedPods, pod)
+		}
+	}
+	updatedZonePodsMap := r.PodZoneHelper.GetZonePodsMap(ctx, updatedPods)
+
+	canaryZones := []string{}
+	for _, zone := range zoneOrder {
+		if _, updated := updatedZonePodsMap[zone]; !updated && len(zonePodsMap[zone]) > 0 {
+			canaryZones = append(canaryZones, zone)
+		}
+	}
+	if len(canaryZones) == 0 {
+		return r.bakeCanary(ctx, zau, w, zoneOrder, oldPodsCountMap)
+	}
+
+	if !w.AllReplicasReady() || len(oldNotReadyPods) > 0 {
+		r.Logger.Info("There are unhealthy replicas, skipping canary update")
+		return nil, false, nil
+	}
+
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(zau.Spec.MaxUnavailable, int(w.Replicas()), true)
+	if err != nil {
+		r.Logger.Error(err, "Failed to compute maxUnavailable")
+		return nil, false, err
+	}
+	canaryZones = canaryZones[:integer.IntMin(len(canaryZones), maxUnavailable)]
+
+	alarms, pauseReason, err := r.checkZonesPauseAlarms(ctx, zau, canaryZones)
+	if err != nil {
+		return nil, false, err
+	}
+	if pauseReason == opsv1.PauseReasonAlarm && zau.Spec.RollbackOnAlarm && canRollback(zau, w) {
+		return nil, false, r.rollback(ctx, zau, w, pauseAlarmMessage(alarms))
+	}
+	if pauseReason != "" {
+		r.Logger.Info("Pausing rollout", "reason", pauseReason, "alarms", alarms)
+		recheckTime := time.Now().Add(requeueInterval)
+		return &recheckTime, false, r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, pauseReason, alarms)
+	}
+
+	decision, err := r.checkZonesHealthGate(ctx, zau, w, canaryZones)
+	if err != nil {
+		return nil, false, err
+	}
+	if decision != utils.HealthGateProceed {
+		recheckTime := time.Now().Add(requeueInterval)
+		if decision == utils.HealthGatePause {
+			return &recheckTime, false, r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, opsv1.PauseReasonHealthGate, nil)
+		}
+		return &recheckTime, false, nil
+	}
+	canaryPods := make([]*v1.Pod, 0, len(canaryZones))
+	for _, zone := range canaryZones {
+		canaryPods = append(canaryPods, zonePodsMap[zone][0])
+	}
+
+	updateStep := zau.Status.UpdateStep
+	if zau.Status.UpdateRevision != w.UpdateRevision() {
+		updateStep = 0
+	}
+
+	canaryPods, blockedPods, err := r.replacePods(ctx, zau, w, canaryPods)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(canaryPods) == 0 && len(blockedPods) > 0 {
+		recheckTime, err := r.retryBlockedPods(ctx, zau, blockedPods)
+		return recheckTime, false, err
+	}
+	r.Recorder.Eventf(zau, v1.EventTypeNormal, "CanaryDeleted", "Deleted %d canary pods in zones %s: %s",
+		len(canaryPods), strings.Join(canaryZones, ", "), joinPodNames(canaryPods))
+
+	zau.Status.BlockedPods = blockedPods
+	zau.Status.UpdatingZone = ""
+	zau.Status.UpdatingZones = nil
+	zau.Status.CanaryBakeEndTime = nil
+	return nil, false, r.updateZauStatus(ctx, zau, w, zoneOrder, "", updateStep+1, int32(len(canaryPods)), oldPodsCountMap, "", nil)
+}
+
+// bakeCanary waits for the canary BakeDuration once the canary pods are updated and ready in all zones,
+// and then completes the canary phase. It returns true once the canary phase is completed.
+func (r *ZoneAwareUpdateReconciler) bakeCanary(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	zoneOrder []string, oldPodsCountMap map[string]int32) (*time.Time, bool, error) {
+
+	if zau.Spec.Canary.BakeDuration != nil {
+		now := time.Now()
+		if zau.Status.CanaryBakeEndTime == nil {
+			bakeEndTime := metav1.NewTime(now.Add(zau.Spec.Canary.BakeDuration.Duration))
+			zau.Status.CanaryBakeEndTime = &bakeEndTime
+			r.Recorder.Eventf(zau, v1.EventTypeNormal, "CanaryBaking", "Canary pods updated in all zones, baking until %s",
+				bakeEndTime.Format(time.RFC3339))
+			if err := r.Client.Status().Update(ctx, zau); err != nil {
+				return nil, false, err
+			}
 		}
-		if pause {
-			r.Logger.Info("PauseRolloutAlarm is in alarm", "alarm", zau.Spec.PauseRolloutAlarm)
-			r.updateZauStatus(ctx, zau, sts, zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, true)
-			return true, nil
+		if bakeEndTime := zau.Status.CanaryBakeEndTime.Time; now.Before(bakeEndTime) {
+			alarms, pauseReason, err := r.checkZonesPauseAlarms(ctx, zau, zoneOrder)
+			if err != nil {
+				return nil, false, err
+			}
+			if pauseReason == opsv1.PauseReasonAlarm && zau.Spec.RollbackOnAlarm && canRollback(zau, w) {
+				return nil, false, r.rollback(ctx, zau, w, pauseAlarmMessage(alarms))
+			}
+			r.Logger.Info("Baking canary pods", "canaryBakeEndTime", bakeEndTime)
+			recheckTime := now.Add(requeueInterval)
+			if bakeEndTime.Before(recheckTime) {
+				recheckTime = bakeEndTime
+			}
+			return &recheckTime, false, r.updateZauStatus(ctx, zau, w, zoneOrder, "", zau.Status.UpdateStep, zau.Status.DeletedReplicas, oldPodsCountMap, pauseReason, alarms)
 		}
 	}
 
-	r.Logger.Info("Proceeding with zone update", "zone", firstZone)
-	return false, r.deletePods(ctx, zau, sts, zonePodsMap[firstZone], oldPodsCountMap)
+	r.Recorder.Eventf(zau, v1.EventTypeNormal, "CanaryCompleted", "Canary pods updated in all zones, updating zones one by one")
+	zau.Status.CanaryRevision = w.UpdateRevision()
+	zau.Status.CanaryBakeEndTime = nil
+	// The zones are updated from the first step
+	zau.Status.UpdateStep = 0
+	return nil, true, r.Client.Status().Update(ctx, zau)
+}
+
+// isRollingBack returns true when the workload is being rolled back after the PauseRolloutAlarm went into alarm,
+// or the ProgressDeadlineSeconds was exceeded.
+func isRollingBack(zau *opsv1.ZoneAwareUpdate, w workload) bool {
+	return zau.Status.Rollback != nil && zau.Status.Rollback.ToRevision == w.UpdateRevision()
+}
+
+// canRollback returns true if the workload can be rolled back to its CurrentRevision.
+// The same revision is not rolled back twice, as the workload may not reflect the rollback yet.
+func canRollback(zau *opsv1.ZoneAwareUpdate, w workload) bool {
+	return !zau.Spec.DryRun && w.CurrentRevision() != w.UpdateRevision() &&
+		(zau.Status.Rollback == nil || zau.Status.Rollback.FromRevision != w.UpdateRevision())
+}
+
+// rollback restores the pod template of the workload CurrentRevision. The pods already updated are then
+// replaced by the rollout, most recently updated first.
+func (r *ZoneAwareUpdateReconciler) rollback(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload, reason string) error {
+	from, to := w.UpdateRevision(), w.CurrentRevision()
+	r.Logger.Info("Rolling back workload", "reason", reason, "kind", w.Kind(), "name", w.Name(), "from", from, "to", to)
+	if err := w.Rollback(ctx); err != nil {
+		r.Logger.Error(err, "Failed to roll back workload", "kind", w.Kind(), "name", w.Name())
+		r.Recorder.Eventf(zau, v1.EventTypeWarning, "RollbackFailed", "Failed to roll back %s %s from revision %s to %s: %v",
+			w.Kind(), w.Name(), from, to, err)
+		return err
+	}
+	r.Recorder.Eventf(zau, v1.EventTypeWarning, "RolledBack", "%s, rolled back %s %s from revision %s to %s",
+		reason, w.Kind(), w.Name(), from, to)
+
+	zau.Status.Rollback = &opsv1.RollbackStatus{FromRevision: from, ToRevision: to, Time: metav1.Now()}
+	return r.Client.Status().Update(ctx, zau)
+}
+
+// checkProgressDeadline sets the Failed condition when updated pods are not ready for longer than the
+// ProgressDeadlineSeconds, and rolls back the workload if RollbackOnFailure is set. Until then, it returns
+// the time when the deadline is exceeded, so the ZAU is checked again.
+func (r *ZoneAwareUpdateReconciler) checkProgressDeadline(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	notReadyPods []*v1.Pod) (*time.Time, error) {
+	if zau.Spec.ProgressDeadlineSeconds == nil {
+		return nil, nil
+	}
+	deadline := time.Duration(*zau.Spec.ProgressDeadlineSeconds) * time.Second
+	now := time.Now()
+
+	var stuckPods []*v1.Pod
+	var podNames []string
+	var recheckTime *time.Time
+	for _, pod := range notReadyPods {
+		startTime := utils.GetPodNotReadyTime(pod)
+		if zau.Status.ResumedTime != nil && zau.Status.ResumedTime.Time.After(startTime) {
+			startTime = zau.Status.ResumedTime.Time
+		}
+		deadlineTime := startTime.Add(deadline)
+		if deadlineTime.After(now) {
+			if recheckTime == nil || deadlineTime.Before(*recheckTime) {
+				recheckTime = &deadlineTime
+			}
+			continue
+		}
+		stuckPods = append(stuckPods, pod)
+		podNames = append(podNames, pod.Name)
+	}
+	if len(stuckPods) == 0 {
+		return recheckTime, nil
+	}
+
+	zones := []string{}
+	for zone := range r.PodZoneHelper.GetZonePodsMap(ctx, stuckPods) {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	message := fmt.Sprintf("Pods %s in zones %s not ready for more than %v",
+		strings.Join(podNames, ", "), strings.Join(zones, ", "), deadline)
+	r.Logger.Info("Progress deadline exceeded", "pods", podNames, "zones", zones, "deadline", deadline)
+	return nil, r.failRollout(ctx, zau, w, opsv1.ProgressDeadlineExceededReason, message, "Progress deadline exceeded")
+}
+
+// failRollout sets the Failed and Degraded conditions, and rolls back the workload if RollbackOnFailure is set.
+func (r *ZoneAwareUpdateReconciler) failRollout(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	reason, message, rollbackReason string) error {
+	condition := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)
+	changed := condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != reason ||
+		condition.Message != message
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		r.Recorder.Event(zau, v1.EventTypeWarning, reason, message)
+	}
+	for _, conditionType := range []string{opsv1.ZoneAwareUpdateConditionFailed, opsv1.ZoneAwareUpdateConditionDegraded} {
+		utils.SetCondition(&zau.Status.Conditions, conditionType, true, reason, message, zau.Generation)
+	}
+
+	if zau.Spec.RollbackOnFailure && canRollback(zau, w) {
+		return r.rollback(ctx, zau, w, rollbackReason)
+	}
+	if !changed {
+		return nil
+	}
+	return r.Client.Status().Update(ctx, zau)
+}
+
+// checkZonesHealthGate calls the HealthGate for each zone, and returns the first decision that doesn't proceed.
+func (r *ZoneAwareUpdateReconciler) checkZonesHealthGate(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	zones []string) (utils.HealthGateDecision, error) {
+	for _, zone := range zones {
+		decision, err := r.checkHealthGate(ctx, zau, w, zone)
+		if err != nil || decision != utils.HealthGateProceed {
+			if decision == utils.HealthGatePause {
+				r.Logger.Info("Health gate paused the rollout", "zone", zone)
+			}
+			return decision, err
+		}
+	}
+	return utils.HealthGateProceed, nil
 }
 
-func (r *ZoneAwareUpdateReconciler) getStatefulSetPods(ctx context.Context, sts *apps.StatefulSet) ([]*v1.Pod, error) {
-	labelSelector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+// checkHealthGate calls the HealthGate before updating pods in the zone. Failures are returned as errors with
+// the Retry policy, so the HealthGate is retried with exponential backoff. With the Fail policy, the rollout
+// fails and no decision is returned.
+func (r *ZoneAwareUpdateReconciler) checkHealthGate(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	zone string) (utils.HealthGateDecision, error) {
+	gate := zau.Spec.HealthGate
+	if gate == nil {
+		return utils.HealthGateProceed, nil
+	}
+	if r.HealthGateChecker == nil {
+		return "", fmt.Errorf("HealthGate is set but no health gate checker is configured")
+	}
+
+	step := zau.Status.UpdateStep + 1
+	if zau.Status.UpdateRevision != w.UpdateRevision() {
+		step = 1
+	}
+	timeout := defaultHealthGateTimeout
+	if gate.Timeout != nil {
+		timeout = gate.Timeout.Duration
+	}
+	gateCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	decision, err := r.HealthGateChecker.Check(gateCtx, gate.URL, utils.HealthGateRequest{
+		Namespace: zau.Namespace,
+		Name:      zau.Name,
+		Zone:      zone,
+		Step:      step,
+		Revision:  w.UpdateRevision(),
+	})
 	if err != nil {
-		r.Logger.Error(err, "GetLabelSelector failed", "sts", sts.Name)
-		return []*v1.Pod{}, err
+		r.Logger.Error(err, "Health gate failed", "url", gate.URL, "zone", zone, "step", step)
+		if gate.FailurePolicy == opsv1.HealthGateFail {
+			message := fmt.Sprintf("Health gate %s failed before step %d in zone %s: %v", gate.URL, step, zone, err)
+			return "", r.failRollout(ctx, zau, w, opsv1.HealthGateFailedReason, message, "Health gate failed")
+		}
+		return "", fmt.Errorf("health gate %s failed: %w", gate.URL, err)
+	}
+	return decision, nil
+}
+
+// completedZones returns the UpdatingZones whose pods are all updated, when the rollout is moving to the next zones.
+func completedZones(zau *opsv1.ZoneAwareUpdate, w workload, nextZones []string) []string {
+	if zau.Status.UpdateRevision != w.UpdateRevision() {
+		return nil
 	}
+	var completed []string
+	for _, zone := range updatingZones(zau) {
+		if !utils.ContainsString(nextZones, zone) {
+			completed = append(completed, zone)
+		}
+	}
+	return completed
+}
 
-	listOptions := &client.ListOptions{Namespace: sts.Namespace, LabelSelector: labelSelector}
-	podList := &v1.PodList{}
-	if err := r.List(ctx, podList, listOptions); err != nil {
-		r.Logger.Error(err, "Unable to list pods")
-		return []*v1.Pod{}, err
+// awaitingApproval returns true if a manual approval is required before updating pods in the zone, one of the
+// next zones to be updated.
+func (r *ZoneAwareUpdateReconciler) awaitingApproval(zau *opsv1.ZoneAwareUpdate, w workload, nextZones []string,
+	zone string, updatedPods int) bool {
+	var approvedZones []string
+	if zau.Status.UpdateRevision == w.UpdateRevision() {
+		approvedZones = zau.Status.ApprovedZones
 	}
 
-	pods := make([]*v1.Pod, 0, len(podList.Items))
-	for i := range podList.Items {
-		pods = append(pods, &podList.Items[i])
+	if zau.Spec.RequireZoneApproval && len(completedZones(zau, w, nextZones)) > 0 &&
+		!utils.ContainsString(updatingZones(zau), zone) && !utils.ContainsString(approvedZones, zone) {
+		return true
 	}
-	return pods, nil
+
+	// Any approval in the revision, even for another zone, means the first pods were checked
+	if zau.Spec.ApprovalAfterPods != nil && updatedPods >= int(*zau.Spec.ApprovalAfterPods) && len(approvedZones) == 0 {
+		return true
+	}
+
+	return false
+}
+
+// bakeZone returns true while the updated zones, whose pods are all updated, are baking.
+// The bake starts the first time it's called after the zones are updated.
+func (r *ZoneAwareUpdateReconciler) bakeZone(ctx context.Context, zau *opsv1.ZoneAwareUpdate, updatedZones []string) (bool, error) {
+	now := metav1.Now()
+	if zau.Status.NextZoneEligibleTime == nil {
+		eligibleTime := metav1.NewTime(now.Add(zau.Spec.ZoneBakeDuration.Duration))
+		zau.Status.NextZoneEligibleTime = &eligibleTime
+		if err := r.Client.Status().Update(ctx, zau); err != nil {
+			return false, err
+		}
+		r.Logger.Info("Zone updated, starting bake", "zones", updatedZones, "nextZoneEligibleTime", eligibleTime)
+		r.Recorder.Eventf(zau, v1.EventTypeNormal, "ZoneBaking", "All pods in zone %s are updated, baking until %s",
+			strings.Join(updatedZones, ", "), eligibleTime.UTC().Format(time.RFC3339))
+	}
+	return now.Before(zau.Status.NextZoneEligibleTime), nil
 }
 
 // Sorting pods so updates are always done in a consistent order.
-// Pod N -> 0
-func (r *ZoneAwareUpdateReconciler) sortPods(pods []*v1.Pod) {
-	sort.Slice(pods, func(i, j int) bool {
+// Pod N -> 0, or newest -> oldest for pods without an ordinal (e.g. Deployment pods).
+// When rolling back, pods with an ordinal are sorted 0 -> N, so the pods updated last are replaced first.
+// Pods with the same ordinal from different StatefulSets are sorted by name.
+func (r *ZoneAwareUpdateReconciler) sortPods(pods []*v1.Pod, rollingBack bool) {
+	sort.SliceStable(pods, func(i, j int) bool {
 		parts := strings.Split(pods[i].Name, "-")
-		idI, err := strconv.Atoi(parts[len(parts)-1])
-		if err != nil {
-			return false
-		}
+		idI, errI := strconv.Atoi(parts[len(parts)-1])
 
 		parts = strings.Split(pods[j].Name, "-")
-		idJ, err := strconv.Atoi(parts[len(parts)-1])
-		if err != nil {
-			return false
+		idJ, errJ := strconv.Atoi(parts[len(parts)-1])
+
+		if errI == nil && errJ == nil {
+			if idI != idJ {
+				return (idI > idJ) != rollingBack
+			}
+			return pods[i].Name > pods[j].Name
+		}
+		if !pods[i].CreationTimestamp.Equal(&pods[j].CreationTimestamp) {
+			return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
 		}
-		return idI > idJ
+		return pods[i].Name > pods[j].Name
 	})
 }
 
-func (r *ZoneAwareUpdateReconciler) updateStatefulSetRevision(ctx context.Context, sts *apps.StatefulSet) error {
-
-	if sts.Status.CurrentRevision != sts.Status.UpdateRevision {
-		r.Logger.Info("Updating statefulset current revision",
-			"current", sts.Status.CurrentRevision, "update", sts.Status.UpdateRevision)
-		sts.Status.CurrentRevision = sts.Status.UpdateRevision
-		return r.Client.Status().Update(ctx, sts)
+func (r *ZoneAwareUpdateReconciler) completeRollout(ctx context.Context, w workload) error {
+	if w.CurrentRevision() != w.UpdateRevision() {
+		r.Logger.Info("Updating workload current revision", "kind", w.Kind(), "name", w.Name(),
+			"current", w.CurrentRevision(), "update", w.UpdateRevision())
 	}
-	return nil
+	return w.CompleteRollout(ctx)
 }
 
 func (r *ZoneAwareUpdateReconciler) updateZauStatus(ctx context.Context, zau *opsv1.ZoneAwareUpdate,
-	sts *apps.StatefulSet, step int32, deletedPods int32, oldPodsCountMap map[string]int32, pausedRollout bool) error {
+	w workload, zoneOrder []string, pendingZone string, step int32, deletedPods int32, oldPodsCountMap map[string]int32,
+	pauseReason opsv1.PauseReason, pausingAlarms []string) error {
+
+	phase := opsv1.ZoneAwareUpdateProgressing
+	if pendingZone != "" {
+		phase = opsv1.ZoneAwareUpdateAwaitingApproval
+	} else if pauseReason != "" {
+		phase = opsv1.ZoneAwareUpdatePaused
+	} else if len(oldPodsCountMap) == 0 {
+		phase = opsv1.ZoneAwareUpdateCompleted
+	} else if isRollingBack(zau, w) {
+		phase = opsv1.ZoneAwareUpdateRollingBack
+	} else if inCanaryPhase(zau, w) {
+		phase = opsv1.ZoneAwareUpdateCanary
+	}
+
+	conditions := zauConditions(zau, w, phase, pendingZone, pauseReason, pausingAlarms, oldPodsCountMap)
 
-	if reflect.DeepEqual(zau.Status.CurrentRevision, sts.Status.CurrentRevision) &&
-		reflect.DeepEqual(zau.Status.UpdateRevision, sts.Status.UpdateRevision) &&
+	if reflect.DeepEqual(zau.Status.CurrentRevision, w.CurrentRevision()) &&
+		reflect.DeepEqual(zau.Status.UpdateRevision, w.UpdateRevision()) &&
+		reflect.DeepEqual(zau.Status.ZoneOrder, zoneOrder) &&
+		reflect.DeepEqual(zau.Status.Phase, phase) &&
+		reflect.DeepEqual(zau.Status.PendingZone, pendingZone) &&
 		reflect.DeepEqual(zau.Status.UpdateStep, step) &&
 		reflect.DeepEqual(zau.Status.DeletedReplicas, deletedPods) &&
 		reflect.DeepEqual(zau.Status.OldReplicas, oldPodsCountMap) &&
-		reflect.DeepEqual(zau.Status.PausedRollout, pausedRollout) {
+		reflect.DeepEqual(zau.Status.PausedRollout, pauseReason != "") &&
+		reflect.DeepEqual(zau.Status.PauseReason, pauseReason) &&
+		reflect.DeepEqual(zau.Status.PausingAlarms, pausingAlarms) &&
+		reflect.DeepEqual(zau.Status.Conditions, conditions) &&
+		zau.Status.ObservedGeneration == zau.Generation {
 		return nil
 	}
 
-	zau.Status.CurrentRevision = sts.Status.CurrentRevision
-	zau.Status.UpdateRevision = sts.Status.UpdateRevision
+	if pendingZone != "" && zau.Status.PendingZone != pendingZone {
+		r.Recorder.Eventf(zau, v1.EventTypeNormal, "AwaitingApproval", "Waiting for a manual approval to update zone %s", pendingZone)
+	}
+	if pauseReason != zau.Status.PauseReason || !reflect.DeepEqual(pausingAlarms, zau.Status.PausingAlarms) {
+		switch pauseReason {
+		case opsv1.PauseReasonAlarm:
+			r.Recorder.Eventf(zau, v1.EventTypeWarning, "PausedByAlarm", "Rollout paused, breaching pause alarms: %s",
+				strings.Join(pausingAlarms, ", "))
+		case opsv1.PauseReasonManual:
+			r.Recorder.Event(zau, v1.EventTypeNormal, "Paused", "Rollout paused through the paused spec field")
+		case opsv1.PauseReasonAlarmFailure:
+			r.Recorder.Event(zau, v1.EventTypeWarning, "PausedByAlarmFailure",
+				"Rollout paused, the state of the pause alarms can't be retrieved")
+		case opsv1.PauseReasonHealthGate:
+			r.Recorder.Eventf(zau, v1.EventTypeWarning, "PausedByHealthGate", "Rollout paused, health gate %s returned 409 Conflict",
+				zau.Spec.HealthGate.URL)
+		default:
+			r.Recorder.Event(zau, v1.EventTypeNormal, "Resumed", "Rollout resumed")
+		}
+	}
+
+	if zau.Status.UpdateRevision != w.UpdateRevision() {
+		zau.Status.ApprovedZones = nil
+	}
+	if zau.Status.Rollback != nil && zau.Status.Rollback.FromRevision != w.UpdateRevision() &&
+		zau.Status.Rollback.ToRevision != w.UpdateRevision() {
+		// A new revision is rolled out
+		zau.Status.Rollback = nil
+	}
+
+	zau.Status.CurrentRevision = w.CurrentRevision()
+	zau.Status.UpdateRevision = w.UpdateRevision()
+	zau.Status.ZoneOrder = zoneOrder
+	zau.Status.Phase = phase
+	zau.Status.PendingZone = pendingZone
 	zau.Status.UpdateStep = step
 	zau.Status.DeletedReplicas = deletedPods
-	zau.Status.PausedRollout = pausedRollout
+	if zau.Status.PauseReason == opsv1.PauseReasonManual && pauseReason != opsv1.PauseReasonManual {
+		now := metav1.Now()
+		zau.Status.ResumedTime = &now
+	}
+	if pauseReason == "" {
+		zau.Status.PausedTime = nil
+	} else if zau.Status.PauseReason != pauseReason || zau.Status.PausedTime == nil {
+		now := metav1.Now()
+		zau.Status.PausedTime = &now
+	}
+	zau.Status.PausedRollout = pauseReason != ""
+	zau.Status.PauseReason = pauseReason
+	zau.Status.PausingAlarms = pausingAlarms
+	zau.Status.Conditions = conditions
+	zau.Status.ObservedGeneration = zau.Generation
 
 	if zau.Status.OldReplicas == nil || len(zau.Status.OldReplicas) == 0 {
 		zau.Status.OldReplicas = oldPodsCountMap
@@ -305,64 +1023,399 @@ func (r *ZoneAwareUpdateReconciler) updateZauStatus(ctx context.Context, zau *op
 	return nil
 }
 
-func (r *ZoneAwareUpdateReconciler) pauseRollout(ctx context.Context, zau *opsv1.ZoneAwareUpdate) (bool, error) {
-	if zau.Spec.PauseRolloutAlarm == "" {
-		return false, nil
+// zauConditions returns the ZAU conditions for the given rollout phase. The Failed condition is kept
+// while rolling back the failed revision, and removed otherwise.
+func zauConditions(zau *opsv1.ZoneAwareUpdate, w workload, phase opsv1.ZoneAwareUpdatePhase, pendingZone string,
+	pauseReason opsv1.PauseReason, pausingAlarms []string, oldPodsCountMap map[string]int32) []metav1.Condition {
+
+	conditions := append([]metav1.Condition{}, zau.Status.Conditions...)
+	rollingBack := isRollingBack(zau, w)
+	if !rollingBack {
+		meta.RemoveStatusCondition(&conditions, opsv1.ZoneAwareUpdateConditionFailed)
+	}
+	setCondition := func(conditionType string, status bool, reason, message string) {
+		utils.SetCondition(&conditions, conditionType, status, reason, message, zau.Generation)
+	}
+
+	oldPods := int32(0)
+	for _, count := range oldPodsCountMap {
+		oldPods += count
+	}
+	var message string
+	switch phase {
+	case opsv1.ZoneAwareUpdateAwaitingApproval:
+		message = fmt.Sprintf("Waiting for a manual approval to update zone %s", pendingZone)
+	case opsv1.ZoneAwareUpdatePaused:
+		message = fmt.Sprintf("Rollout of revision %s is paused", w.UpdateRevision())
+	case opsv1.ZoneAwareUpdateCompleted:
+		message = fmt.Sprintf("All pods are in revision %s", w.UpdateRevision())
+	case opsv1.ZoneAwareUpdateRollingBack:
+		message = fmt.Sprintf("Rolling back %d pods to revision %s", oldPods, w.UpdateRevision())
+	case opsv1.ZoneAwareUpdateCanary:
+		message = fmt.Sprintf("Updating a canary pod in each zone to revision %s", w.UpdateRevision())
+	default:
+		message = fmt.Sprintf("Updating %d pods to revision %s", oldPods, w.UpdateRevision())
+	}
+	setCondition(opsv1.ZoneAwareUpdateConditionProgressing,
+		phase == opsv1.ZoneAwareUpdateProgressing || phase == opsv1.ZoneAwareUpdateRollingBack ||
+			phase == opsv1.ZoneAwareUpdateCanary, string(phase), message)
+	setCondition(opsv1.ZoneAwareUpdateConditionAvailable, phase == opsv1.ZoneAwareUpdateCompleted, string(phase), message)
+
+	switch pauseReason {
+	case opsv1.PauseReasonAlarm:
+		setCondition(opsv1.ZoneAwareUpdateConditionPaused, true, string(pauseReason),
+			pauseAlarmMessage(pausingAlarms))
+	case opsv1.PauseReasonManual:
+		setCondition(opsv1.ZoneAwareUpdateConditionPaused, true, string(pauseReason), "Rollout paused through the paused spec field")
+	case opsv1.PauseReasonAlarmFailure:
+		setCondition(opsv1.ZoneAwareUpdateConditionPaused, true, string(pauseReason),
+			"The state of the pause alarms can't be retrieved, see the AlarmsUnavailable condition")
+	case opsv1.PauseReasonHealthGate:
+		setCondition(opsv1.ZoneAwareUpdateConditionPaused, true, string(pauseReason),
+			fmt.Sprintf("Health gate %s returned 409 Conflict", zau.Spec.HealthGate.URL))
+	default:
+		setCondition(opsv1.ZoneAwareUpdateConditionPaused, false, "NotPaused", "")
+	}
+
+	if failed := meta.FindStatusCondition(conditions, opsv1.ZoneAwareUpdateConditionFailed); failed != nil {
+		setCondition(opsv1.ZoneAwareUpdateConditionDegraded, true, failed.Reason, failed.Message)
+	} else if rollingBack {
+		setCondition(opsv1.ZoneAwareUpdateConditionDegraded, true, "RolledBack",
+			fmt.Sprintf("Revision %s was rolled back to %s", zau.Status.Rollback.FromRevision, zau.Status.Rollback.ToRevision))
+	} else {
+		setCondition(opsv1.ZoneAwareUpdateConditionDegraded, false, "AsExpected", "")
+	}
+	return conditions
+}
+
+// checkZonesPauseAlarms checks the pause alarms of each zone, and returns the first pause alarms pausing the rollout.
+func (r *ZoneAwareUpdateReconciler) checkZonesPauseAlarms(ctx context.Context, zau *opsv1.ZoneAwareUpdate,
+	zones []string) ([]string, opsv1.PauseReason, error) {
+	for _, zone := range zones {
+		alarms, pauseReason, err := r.checkPauseAlarms(ctx, zau, zone)
+		if err != nil || pauseReason != "" {
+			return alarms, pauseReason, err
+		}
+	}
+	return nil, "", nil
+}
+
+// checkPauseAlarms returns the pause alarms pausing the rollout while the zone is updated, and the pause reason.
+// When the state of the alarms can't be retrieved, the AlarmsUnavailable condition is set with the error, and
+// the rollout is paused with the AlarmFailure reason, unless the Proceed AlarmFailurePolicy is set and the
+// MaxAlarmOutageDuration is not exceeded.
+func (r *ZoneAwareUpdateReconciler) checkPauseAlarms(ctx context.Context, zau *opsv1.ZoneAwareUpdate,
+	zone string) ([]string, opsv1.PauseReason, error) {
+	alarms, alarmErr := r.pausingAlarms(ctx, zau, zone)
+	condition := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable)
+	unavailable := condition != nil && condition.Status == metav1.ConditionTrue
+
+	if alarmErr == nil {
+		if unavailable {
+			r.Recorder.Event(zau, v1.EventTypeNormal, "AlarmsAvailable", "The state of the pause alarms is available again")
+			utils.SetCondition(&zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable, false,
+				"AlarmsAvailable", "", zau.Generation)
+			if err := r.Client.Status().Update(ctx, zau); err != nil {
+				return nil, "", err
+			}
+		}
+		if len(alarms) > 0 {
+			return alarms, opsv1.PauseReasonAlarm, nil
+		}
+		return nil, "", nil
+	}
+
+	r.Logger.Error(alarmErr, "Failed to get the state of the pause alarms", "policy", zau.Spec.AlarmFailurePolicy)
+	message := alarmErr.Error()
+	if !unavailable {
+		r.Recorder.Event(zau, v1.EventTypeWarning, "AlarmsUnavailable", message)
+	}
+	if !unavailable || condition.Message != message {
+		utils.SetCondition(&zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable, true,
+			"AlarmBackendError", message, zau.Generation)
+		if err := r.Client.Status().Update(ctx, zau); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if zau.Spec.AlarmFailurePolicy == opsv1.AlarmFailureProceed {
+		outageStart := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable).LastTransitionTime
+		if zau.Spec.MaxAlarmOutageDuration == nil || time.Since(outageStart.Time) < zau.Spec.MaxAlarmOutageDuration.Duration {
+			r.Logger.Info("Proceeding with the rollout while the pause alarms are unavailable", "since", outageStart)
+			return nil, "", nil
+		}
+	}
+	return nil, opsv1.PauseReasonAlarmFailure, nil
+}
+
+// pausingAlarms returns the pause alarms of the zone being updated in ALARM state, or in INSUFFICIENT_DATA state
+// when PauseOnInsufficientData is set. A breaching PauseRolloutQuery is returned as PauseRolloutQuery.
+func (r *ZoneAwareUpdateReconciler) pausingAlarms(ctx context.Context, zau *opsv1.ZoneAwareUpdate, zone string) ([]string, error) {
+	alarms := utils.PauseAlarmNames(zau, zone)
+	if len(alarms) == 0 && zau.Spec.PauseRolloutQuery == nil {
+		return nil, nil
 	}
 	if zau.Spec.IgnoreAlarm {
-		r.Logger.Info("Ignoring PauseRolloutAlarm")
-		return false, nil
+		r.Logger.Info("Ignoring pause alarms")
+		return nil, nil
 	}
 
-	state, err := r.AlarmStateProvider.AlarmState(ctx, zau.Spec.PauseRolloutAlarm)
-	if err != nil {
-		return true, err
+	var pausing []string
+	for _, alarm := range alarms {
+		state, err := r.AlarmStateProvider.AlarmState(ctx, alarm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the state of alarm %s: %w", alarm, err)
+		}
+		if pausingState(zau, state) {
+			pausing = append(pausing, alarm)
+		}
 	}
-	if state == cwtypes.StateValueAlarm {
-		return true, nil
+
+	if query := zau.Spec.PauseRolloutQuery; query != nil {
+		if r.QueryStateProvider == nil {
+			return nil, fmt.Errorf("PauseRolloutQuery is set but no Prometheus address is configured")
+		}
+		threshold, err := strconv.ParseFloat(query.Threshold, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PauseRolloutQuery threshold %q: %w", query.Threshold, err)
+		}
+		state, err := r.QueryStateProvider.QueryState(ctx, strings.ReplaceAll(query.Query, utils.ZonePlaceholder, zone), threshold)
+		if err != nil {
+			return nil, err
+		}
+		if pausingState(zau, state) {
+			pausing = append(pausing, pauseRolloutQueryAlarm)
+		}
 	}
 
-	return false, nil
+	return pausing, nil
+}
+
+func pausingState(zau *opsv1.ZoneAwareUpdate, state cwtypes.StateValue) bool {
+	return state == cwtypes.StateValueAlarm ||
+		(zau.Spec.PauseOnInsufficientData && state == cwtypes.StateValueInsufficientData)
+}
+
+// pauseAlarmMessage describes the alarms pausing the rollout.
+func pauseAlarmMessage(alarms []string) string {
+	return fmt.Sprintf("Breaching pause alarms: %s", strings.Join(alarms, ", "))
 }
 
 func (r *ZoneAwareUpdateReconciler) deletePods(ctx context.Context,
-	zau *opsv1.ZoneAwareUpdate, sts *apps.StatefulSet, pods []*v1.Pod, oldPodsCountMap map[string]int32) error {
+	zau *opsv1.ZoneAwareUpdate, w workload, zonePodsMap map[string][]*v1.Pod, zones []string, zoneOrder []string,
+	oldPodsCountMap map[string]int32, newZone bool) (*time.Time, error) {
 
-	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(zau.Spec.MaxUnavailable, int(sts.Status.Replicas), true)
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(zau.Spec.MaxUnavailable, int(w.Replicas()), true)
 	if err != nil {
 		r.Logger.Error(err, "Failed to compute maxUnavailable")
-		return err
+		return nil, err
 	}
 
 	updateStep := zau.Status.UpdateStep
 
-	if updateStep > 0 && zau.Status.UpdateRevision != sts.Status.UpdateRevision {
+	if updateStep > 0 && zau.Status.UpdateRevision != w.UpdateRevision() {
 		r.Logger.Info("New update revision found, reseting UpdateStep counter", "previousValue", updateStep)
 		updateStep = 0
 	}
 
-	maxToDelete, err := r.maxPodsToDelete(maxUnavailable, updateStep, zau.Spec.ExponentialFactor)
+	var maxToDelete int
+	var stepIndex int32
+	if len(zau.Spec.Steps) > 0 {
+		if !newZone && updateStep > 0 {
+			stepIndex = integer.Int32Min(zau.Status.StepIndex+1, int32(len(zau.Spec.Steps)-1))
+		}
+		maxToDelete, err = maxPodsInStep(maxUnavailable, int(w.Replicas()), zau.Spec.Steps[stepIndex])
+	} else {
+		maxToDelete, err = r.maxPodsToDelete(maxUnavailable, updateStep, zau.Spec.ExponentialFactor)
+	}
 	if err != nil {
 		r.Logger.Error(err, "Failed to compute the max number of pods to be deleted")
-		return err
+		return nil, err
 	}
 
-	numPodsToDelete := integer.IntMin(len(pods), maxToDelete)
+	// Each zone is updated by up to maxToDelete pods, and no more than maxUnavailable pods across all zones
+	podsToDelete := []*v1.Pod{}
+	for _, zone := range zones {
+		pods := zonePodsMap[zone]
+		numPods := integer.IntMin(integer.IntMin(len(pods), maxToDelete), maxUnavailable-len(podsToDelete))
+		podsToDelete = append(podsToDelete, pods[:numPods]...)
+	}
+	numPodsToDelete := len(podsToDelete)
 
-	for i := 0; i < numPodsToDelete; i++ {
-		pod := pods[i]
-		r.Logger.Info("Found a candidate pod to be deleted", "pod", pod.Name, "revision", pod.Labels[apps.ControllerRevisionHashLabelKey])
+	for _, pod := range podsToDelete {
+		podRev, _ := w.PodRevision(pod)
+		r.Logger.Info("Found a candidate pod to be deleted", "pod", pod.Name, "revision", podRev)
 		if zau.Spec.DryRun {
 			r.Logger.Info("DryRun option enabled, ignoring deletion", "pod", pod.Name)
-		} else {
-			if err := r.Delete(ctx, pod); err != nil {
-				r.Logger.Error(err, "Failed to delete pod")
-				return err
+		}
+	}
+	var blockedPods []opsv1.BlockedPod
+	if zau.Spec.DryRun {
+		r.Recorder.Eventf(zau, v1.EventTypeNormal, "DryRun", "DryRun option enabled, not deleting %d pods in zone %s: %s",
+			numPodsToDelete, strings.Join(zones, ", "), joinPodNames(podsToDelete))
+	} else {
+		podsToDelete, blockedPods, err = r.replacePods(ctx, zau, w, podsToDelete)
+		if err != nil {
+			return nil, err
+		}
+		numPodsToDelete = len(podsToDelete)
+		if numPodsToDelete == 0 && len(blockedPods) > 0 {
+			return r.retryBlockedPods(ctx, zau, blockedPods)
+		}
+		r.Recorder.Eventf(zau, v1.EventTypeNormal, "BatchDeleted", "Deleted %d pods in zone %s (step %d): %s",
+			numPodsToDelete, strings.Join(zones, ", "), updateStep+1, joinPodNames(podsToDelete))
+	}
+
+	zau.Status.BlockedPods = blockedPods
+	zau.Status.StepIndex = stepIndex
+	zau.Status.PlannedStepSize = int32(maxToDelete)
+	return nil, r.updateZauStatus(ctx, zau, w, zoneOrder, "", updateStep+1, int32(numPodsToDelete), oldPodsCountMap, "", nil)
+}
+
+// replacePods replaces the pods with the DeletionMethod of the ZAU. It returns the replaced pods, and the pods
+// whose eviction was blocked by a disruption budget.
+func (r *ZoneAwareUpdateReconciler) replacePods(ctx context.Context, zau *opsv1.ZoneAwareUpdate, w workload,
+	pods []*v1.Pod) ([]*v1.Pod, []opsv1.BlockedPod, error) {
+
+	var blockedPods []opsv1.BlockedPod
+	if zau.Spec.DeletionMethod == opsv1.DeletionMethodEvict && w.Kind() != utils.ControllerKindDeployment.Kind {
+		var err error
+		pods, blockedPods, err = r.evictPods(ctx, zau, pods)
+		if err != nil {
+			r.Logger.Error(err, "Failed to evict pods")
+			return nil, nil, err
+		}
+	} else if err := w.ReplacePods(ctx, pods); err != nil {
+		r.Logger.Error(err, "Failed to delete pods")
+		return nil, nil, err
+	}
+
+	if len(blockedPods) > 0 {
+		r.Recorder.Eventf(zau, v1.EventTypeWarning, "EvictionBlocked", "Eviction of %d pods blocked: %s",
+			len(blockedPods), blockedPodsMessage(blockedPods))
+	}
+	for _, pod := range pods {
+		r.Recorder.Eventf(pod, v1.EventTypeNormal, "DeletedByRollout", "Deleted by ZoneAwareUpdate %s to update to revision %s",
+			zau.Name, w.UpdateRevision())
+	}
+	return pods, blockedPods, nil
+}
+
+// retryBlockedPods records the pods whose eviction was blocked, and returns the time when the step
+// should be retried.
+func (r *ZoneAwareUpdateReconciler) retryBlockedPods(ctx context.Context, zau *opsv1.ZoneAwareUpdate,
+	blockedPods []opsv1.BlockedPod) (*time.Time, error) {
+
+	zau.Status.BlockedPods = blockedPods
+	recheckTime := time.Now().Add(evictionBackoff(blockedPods))
+	r.Logger.Info("Pod evictions blocked, retrying", "blockedPods", len(blockedPods), "recheckTime", recheckTime)
+	return &recheckTime, r.Client.Status().Update(ctx, zau)
+}
+
+// evictPods deletes the pods through the Eviction API. It returns the evicted pods, and the pods whose
+// eviction was blocked by a disruption budget.
+func (r *ZoneAwareUpdateReconciler) evictPods(ctx context.Context, zau *opsv1.ZoneAwareUpdate,
+	pods []*v1.Pod) ([]*v1.Pod, []opsv1.BlockedPod, error) {
+
+	evictedPods := []*v1.Pod{}
+	var blockedPods []opsv1.BlockedPod
+	for _, pod := range pods {
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		err := r.SubResource("eviction").Create(ctx, pod, eviction)
+		switch {
+		case err == nil:
+			evictedPods = append(evictedPods, pod)
+		case errors.IsNotFound(err):
+			r.Logger.Info("Pod not found, skipping eviction", "pod", pod.Name)
+		case evictionBlocked(err):
+			attempts := int32(1)
+			for _, blocked := range zau.Status.BlockedPods {
+				if blocked.Name == pod.Name {
+					attempts = blocked.Attempts + 1
+				}
 			}
+			blockedPods = append(blockedPods, opsv1.BlockedPod{
+				Name:     pod.Name,
+				Budget:   blockingBudget(err),
+				Message:  err.Error(),
+				Attempts: attempts,
+			})
+		default:
+			return evictedPods, blockedPods, err
+		}
+	}
+	return evictedPods, blockedPods, nil
+}
+
+var (
+	// Cause message set by the Eviction API when a PodDisruptionBudget blocks the eviction
+	pdbCauseRegexp = regexp.MustCompile(`The disruption budget (\S+)`)
+	// Message returned by the eviction webhook when a ZoneDisruptionBudget denies the eviction
+	zdbDenialRegexp = regexp.MustCompile(`ZoneDisruptionBudget (\S+)`)
+)
+
+// evictionBlocked returns true if the eviction was denied by a disruption budget, either a PodDisruptionBudget
+// (429 Too Many Requests) or a ZoneDisruptionBudget through the eviction webhook (403 Forbidden). Other errors,
+// such as RBAC denials, are not retried as blocked evictions.
+func evictionBlocked(err error) bool {
+	return errors.IsTooManyRequests(err) || (errors.IsForbidden(err) && blockingBudget(err) != "")
+}
+
+// blockingBudget returns the disruption budget that blocked an eviction, or an empty string if it's unknown.
+func blockingBudget(err error) string {
+	if status, ok := err.(errors.APIStatus); ok && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type != policyv1.DisruptionBudgetCause {
+				continue
+			}
+			if match := pdbCauseRegexp.FindStringSubmatch(cause.Message); match != nil {
+				return "PodDisruptionBudget " + match[1]
+			}
+		}
+	}
+	if match := zdbDenialRegexp.FindStringSubmatch(err.Error()); match != nil {
+		return "ZoneDisruptionBudget " + match[1]
+	}
+	return ""
+}
+
+// evictionBackoff returns the time to wait before retrying blocked evictions, doubling from
+// minEvictionBackoff with the attempts up to maxEvictionBackoff.
+func evictionBackoff(blockedPods []opsv1.BlockedPod) time.Duration {
+	backoff := minEvictionBackoff
+	for _, blocked := range blockedPods {
+		attemptBackoff := minEvictionBackoff
+		for i := int32(1); i < blocked.Attempts && attemptBackoff < maxEvictionBackoff; i++ {
+			attemptBackoff *= 2
+		}
+		if attemptBackoff > backoff {
+			backoff = attemptBackoff
+		}
+	}
+	if backoff > maxEvictionBackoff {
+		backoff = maxEvictionBackoff
+	}
+	return backoff
+}
+
+func blockedPodsMessage(blockedPods []opsv1.BlockedPod) string {
+	messages := make([]string, 0, len(blockedPods))
+	for _, blocked := range blockedPods {
+		if blocked.Budget == "" {
+			messages = append(messages, blocked.Name)
+		} else {
+			messages = append(messages, fmt.Sprintf("%s (%s)", blocked.Name, blocked.Budget))
 		}
 	}
+	return strings.Join(messages, ", ")
+}
 
-	return r.updateZauStatus(ctx, zau, sts, updateStep+1, int32(numPodsToDelete), oldPodsCountMap, false)
+func joinPodNames(pods []*v1.Pod) string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return strings.Join(names, ", ")
 }
 
 func (r *ZoneAwareUpdateReconciler) maxPodsToDelete(maxUnavailable int, updateStep int32, exponentialFactor string) (int, error) {
@@ -384,6 +1437,15 @@ func (r *ZoneAwareUpdateReconciler) maxPodsToDelete(maxUnavailable int, updateSt
 	return numPodsToDelete, nil
 }
 
+// maxPodsInStep returns the number of pods to be updated in a step of the Steps, capped by maxUnavailable.
+func maxPodsInStep(maxUnavailable int, replicas int, step intstr.IntOrString) (int, error) {
+	stepSize, err := intstr.GetScaledValueFromIntOrPercent(&step, replicas, true)
+	if err != nil {
+		return 0, err
+	}
+	return integer.IntMin(stepSize, maxUnavailable), nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ZoneAwareUpdateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -399,6 +1461,16 @@ func (r *ZoneAwareUpdateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &apps.StatefulSet{}},
 			handler.EnqueueRequestsFromMapFunc(r.findZauForStatefulSet),
 		).
+		// watch for changes to deployments
+		Watches(
+			&source.Kind{Type: &apps.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(r.findZauForDeployment),
+		).
+		// watch for changes to daemonsets
+		Watches(
+			&source.Kind{Type: &apps.DaemonSet{}},
+			handler.EnqueueRequestsFromMapFunc(r.findZauForDaemonSet),
+		).
 		Complete(r)
 }
 
@@ -409,34 +1481,59 @@ func (r *ZoneAwareUpdateReconciler) findZauForPod(obj client.Object) []reconcile
 		return []reconcile.Request{}
 	}
 
-	sts, err := r.getPodStatefulSet(pod)
+	kind, owner, err := r.getPodWorkload(pod)
 	if err != nil {
-		r.Logger.Error(err, "Unable to fetch statefulset", "pod", pod.Name)
+		r.Logger.Error(err, "Unable to fetch pod workload", "pod", pod.Name)
 		return []reconcile.Request{}
 	}
 
-	if sts == nil {
-		// No statefulset associated to the pod
+	if owner == nil {
+		// No workload associated to the pod
 		return []reconcile.Request{}
 	}
 
-	return r.findZauForStatefulSet(sts)
+	return r.findZauForWorkload(kind, owner)
 }
 
-func (r *ZoneAwareUpdateReconciler) getPodStatefulSet(pod *v1.Pod) (*apps.StatefulSet, error) {
+// getPodWorkload returns the kind and object of the workload managing the pod,
+// or a nil object if the pod is not managed by a supported workload.
+func (r *ZoneAwareUpdateReconciler) getPodWorkload(pod *v1.Pod) (string, client.Object, error) {
 	controllerRef := metav1.GetControllerOf(pod)
 	if controllerRef == nil {
-		return nil, nil
+		return "", nil, nil
 	}
-	if controllerRef.Kind != utils.ControllerKindSS.Kind {
-		return nil, nil
-	}
-	var sts apps.StatefulSet
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: controllerRef.Name, Namespace: pod.GetNamespace()}, &sts); err != nil {
-		return nil, client.IgnoreNotFound(err)
+	key := types.NamespacedName{Name: controllerRef.Name, Namespace: pod.GetNamespace()}
+
+	switch controllerRef.Kind {
+	case utils.ControllerKindSS.Kind:
+		var sts apps.StatefulSet
+		if err := r.Get(context.TODO(), key, &sts); err != nil {
+			return "", nil, client.IgnoreNotFound(err)
+		}
+		return utils.ControllerKindSS.Kind, &sts, nil
+	case utils.ControllerKindRS.Kind:
+		var rs apps.ReplicaSet
+		if err := r.Get(context.TODO(), key, &rs); err != nil {
+			return "", nil, client.IgnoreNotFound(err)
+		}
+		rsControllerRef := metav1.GetControllerOf(&rs)
+		if rsControllerRef == nil || rsControllerRef.Kind != utils.ControllerKindDeployment.Kind {
+			return "", nil, nil
+		}
+		var deployment apps.Deployment
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: rsControllerRef.Name, Namespace: pod.GetNamespace()}, &deployment); err != nil {
+			return "", nil, client.IgnoreNotFound(err)
+		}
+		return utils.ControllerKindDeployment.Kind, &deployment, nil
+	case utils.ControllerKindDS.Kind:
+		var ds apps.DaemonSet
+		if err := r.Get(context.TODO(), key, &ds); err != nil {
+			return "", nil, client.IgnoreNotFound(err)
+		}
+		return utils.ControllerKindDS.Kind, &ds, nil
 	}
 
-	return &sts, nil
+	return "", nil, nil
 }
 
 func (r *ZoneAwareUpdateReconciler) findZauForStatefulSet(obj client.Object) []reconcile.Request {
@@ -446,46 +1543,66 @@ func (r *ZoneAwareUpdateReconciler) findZauForStatefulSet(obj client.Object) []r
 		return []reconcile.Request{}
 	}
 
-	zau, err := getZauForStatefulSet(r.Client, r.Logger, sts)
-	if err != nil {
-		r.Logger.Error(err, "Error getting ZAU for statefulset", "sts", sts.Name)
+	return r.findZauForWorkload(utils.ControllerKindSS.Kind, sts)
+}
+
+func (r *ZoneAwareUpdateReconciler) findZauForDeployment(obj client.Object) []reconcile.Request {
+	deployment, ok := obj.(*apps.Deployment)
+	if !ok {
+		r.Logger.Info("Failed to convert object to deployment", "obj", obj)
 		return []reconcile.Request{}
 	}
 
-	if zau == nil {
+	return r.findZauForWorkload(utils.ControllerKindDeployment.Kind, deployment)
+}
+
+func (r *ZoneAwareUpdateReconciler) findZauForDaemonSet(obj client.Object) []reconcile.Request {
+	ds, ok := obj.(*apps.DaemonSet)
+	if !ok {
+		r.Logger.Info("Failed to convert object to daemonset", "obj", obj)
 		return []reconcile.Request{}
 	}
 
-	request := reconcile.Request{
-		NamespacedName: types.NamespacedName{
-			Name:      zau.Name,
-			Namespace: zau.Namespace,
-		},
+	return r.findZauForWorkload(utils.ControllerKindDS.Kind, ds)
+}
+
+func (r *ZoneAwareUpdateReconciler) findZauForWorkload(kind string, obj client.Object) []reconcile.Request {
+	zaus, err := getZausForWorkload(r.Client, r.Logger, kind, obj)
+	if err != nil {
+		r.Logger.Error(err, "Error getting ZAU for workload", "kind", kind, "name", obj.GetName())
+		return []reconcile.Request{}
 	}
 
-	return []reconcile.Request{request}
+	requests := make([]reconcile.Request, 0, len(zaus))
+	for _, zau := range zaus {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      zau.Name,
+				Namespace: zau.Namespace,
+			},
+		})
+	}
+
+	return requests
 }
 
-func getZauForStatefulSet(c client.Client, logger logr.Logger, sts *apps.StatefulSet) (*opsv1.ZoneAwareUpdate, error) {
+// getZausForWorkload returns all ZAUs rolling out the workload object of the given kind.
+func getZausForWorkload(c client.Client, logger logr.Logger, kind string, obj client.Object) ([]opsv1.ZoneAwareUpdate, error) {
 	zauList := &opsv1.ZoneAwareUpdateList{}
-	if err := c.List(context.TODO(), zauList, &client.ListOptions{Namespace: sts.GetNamespace()}); err != nil {
+	if err := c.List(context.TODO(), zauList, &client.ListOptions{Namespace: obj.GetNamespace()}); err != nil {
 		return nil, err
 	}
 
 	var matchedZaus []opsv1.ZoneAwareUpdate
-	for _, strategy := range zauList.Items {
-		if strategy.Spec.StatefulSet == sts.GetName() {
-			matchedZaus = append(matchedZaus, strategy)
+	for i := range zauList.Items {
+		if zauTargetsWorkload(&zauList.Items[i], kind, obj) {
+			matchedZaus = append(matchedZaus, zauList.Items[i])
 		}
 	}
 
-	if len(matchedZaus) == 0 {
-		return nil, nil
-	}
-
 	if len(matchedZaus) > 1 {
-		logger.Info("StatefulSet matches multiple ZAUs. Choosing first matched ZAU.", "sset", sts.Name, "zau", matchedZaus[0].Name)
+		logger.Info("Workload matches multiple ZAUs", "kind", kind, "name", obj.GetName(), "zaus", len(matchedZaus))
 	}
 
-	return &matchedZaus[0], nil
+	return matchedZaus, nil
 }
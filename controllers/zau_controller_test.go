@@ -2,9 +2,13 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
@@ -14,25 +18,81 @@ import (
 	. "github.com/onsi/gomega"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type mockAlarmStateProvider struct {
+	state  types.StateValue
+	states map[string]types.StateValue
+	err    error
+}
+
+func (m mockAlarmStateProvider) AlarmState(ctx context.Context, alarmName string) (types.StateValue, error) {
+	if state, ok := m.states[alarmName]; ok {
+		return state, m.err
+	}
+	return m.state, m.err
+}
+
+type mockQueryStateProvider struct {
 	state types.StateValue
 	err   error
 }
 
-func (m mockAlarmStateProvider) AlarmState(ctx context.Context, alarmName string) (types.StateValue, error) {
+func (m mockQueryStateProvider) QueryState(ctx context.Context, query string, threshold float64) (types.StateValue, error) {
 	return m.state, m.err
 }
 
+// deploymentScaleClient records the replicas the deployment controller would set on the ReplicaSets of the
+// deployment after each ReplicaSet patch, as envtest doesn't run the deployment controller.
+type deploymentScaleClient struct {
+	client.Client
+	deployment *apps.Deployment
+	scales     []map[string]int32
+}
+
+func (c *deploymentScaleClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	if _, ok := obj.(*apps.ReplicaSet); !ok {
+		return nil
+	}
+
+	rsList := &apps.ReplicaSetList{}
+	if err := c.List(ctx, rsList, client.InNamespace(c.deployment.Namespace), client.MatchingLabels(c.deployment.Spec.Selector.MatchLabels)); err != nil {
+		return err
+	}
+	var newRS *apps.ReplicaSet
+	var oldRSs []*apps.ReplicaSet
+	for i := range rsList.Items {
+		if utils.EqualIgnoreHash(&rsList.Items[i].Spec.Template, &c.deployment.Spec.Template) {
+			newRS = &rsList.Items[i]
+		} else {
+			oldRSs = append(oldRSs, &rsList.Items[i])
+		}
+	}
+	if scale := deploymentControllerScale(c.deployment, newRS, oldRSs); len(scale) > 0 {
+		c.scales = append(c.scales, scale)
+	}
+	return nil
+}
+
 var _ = Describe("ZAU Controller", func() {
 	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
 	replicas := 9
 	maxUnavailable := 2
 
 	var controller *ZoneAwareUpdateReconciler
+	var recorder *record.FakeRecorder
 
 	BeforeEach(func() {
 		podZoneHelper := podzone.Helper{
@@ -41,10 +101,12 @@ var _ = Describe("ZAU Controller", func() {
 			Cache:  podzone.NewCache(),
 		}
 
+		recorder = record.NewFakeRecorder(100)
 		controller = &ZoneAwareUpdateReconciler{
 			Client:        k8sClient,
 			Logger:        ctrl.Log.WithName("zau-controller-test"),
 			PodZoneHelper: &podZoneHelper,
+			Recorder:      recorder,
 		}
 	})
 
@@ -53,9 +115,9 @@ var _ = Describe("ZAU Controller", func() {
 			It("It should not delete pods", func() {
 				ss, zau, pods := createResources("zau-test1", replicas, maxUnavailable, zones)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				// no pods in terminating state
 				expectNoDeletions(zau, pods)
@@ -67,11 +129,12 @@ var _ = Describe("ZAU Controller", func() {
 				ss, zau, pods := createResources("zau-test20", replicas, maxUnavailable, zones)
 				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				expectEvents(recorder, "ZoneStarted", "BatchDeleted", "DeletedByRollout")
 			})
 
 			It("It should double the number of pods updated after the fist step", func() {
@@ -83,9 +146,9 @@ var _ = Describe("ZAU Controller", func() {
 				testUtils.UpdatePod(pods[6])
 				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				assertContainDeletions(pods, []int{0, 3}) // first two pods in the first zone
 
@@ -113,9 +176,9 @@ var _ = Describe("ZAU Controller", func() {
 				testUtils.UpdatePod(pods[3])
 				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				assertContainDeletions(pods, []int{0}) // first pod in the first zone
 
@@ -145,9 +208,9 @@ var _ = Describe("ZAU Controller", func() {
 				testUtils.UpdatePod(pods[0])
 				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				assertContainDeletions(pods, []int{4, 7}) // last 2 pods in the second zone
 
@@ -163,6 +226,197 @@ var _ = Describe("ZAU Controller", func() {
 			})
 		})
 
+		Context("When all pods in the first zone were updated and ZoneBakeDuration is set", func() {
+			It("It should not delete pods in the second zone before the bake ends", func() {
+				ss, zau, pods := createResources("zau-test24", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				for _, i := range []int{0, 3, 6} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				zau.Status.UpdatingZone = zones[0]
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+				zau.Spec.ZoneBakeDuration = &metav1.Duration{Duration: time.Hour}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(2)))
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[0]))
+				Expect(zau.Status.NextZoneEligibleTime).ShouldNot(BeNil())
+				Expect(zau.Status.NextZoneEligibleTime.Time).Should(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			})
+
+			It("It should delete pods in the second zone after the bake ends", func() {
+				ss, zau, pods := createResources("zau-test25", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				for _, i := range []int{0, 3, 6} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				eligibleTime := metav1.NewTime(time.Now().Add(-time.Minute))
+				zau.Status.UpdatingZone = zones[0]
+				zau.Status.NextZoneEligibleTime = &eligibleTime
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+				zau.Spec.ZoneBakeDuration = &metav1.Duration{Duration: time.Hour}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{4, 7}) // last 2 pods in the second zone
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(3)))
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[1]))
+				Expect(zau.Status.NextZoneEligibleTime).Should(BeNil())
+			})
+		})
+
+		Context("When RequireZoneApproval is set", func() {
+			It("It should wait for approval before updating the second zone", func() {
+				ss, zau, pods := createResources("zau-test28", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.RequireZoneApproval = true
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				for _, i := range []int{0, 3, 6} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				zau.Status.UpdatingZone = zones[0]
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(2)))
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateAwaitingApproval))
+				Expect(zau.Status.PendingZone).Should(Equal(zones[1]))
+			})
+
+			It("It should update the second zone once approved", func() {
+				ss, zau, pods := createResources("zau-test29", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.RequireZoneApproval = true
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				for _, i := range []int{0, 3, 6} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				zau.Status.UpdatingZone = zones[0]
+				zau.Status.ApprovedZones = []string{zones[1]}
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{4, 7}) // last 2 pods in the second zone
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateProgressing))
+				Expect(zau.Status.PendingZone).Should(BeEmpty())
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[1]))
+			})
+		})
+
+		Context("When ApprovalAfterPods is set", func() {
+			It("It should wait for approval once the first pods are updated", func() {
+				ss, zau, pods := createResources("zau-test41", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				approvalAfterPods := int32(1)
+				zau.Spec.ApprovalAfterPods = &approvalAfterPods
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				pods[6].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				testUtils.UpdatePod(pods[6])
+				zau.Status.UpdatingZone = zones[0]
+				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateAwaitingApproval))
+				Expect(zau.Status.PendingZone).Should(Equal(zones[0]))
+			})
+
+			It("It should proceed once any zone is approved in the revision", func() {
+				ss, zau, pods := createResources("zau-test73", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				approvalAfterPods := int32(1)
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				pods[6].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				testUtils.UpdatePod(pods[6])
+				zau.Status.UpdatingZone = zones[0]
+				zau.Status.ApprovedZones = []string{zones[1]}
+				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
+				zau.Spec.ApprovalAfterPods = &approvalAfterPods
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{0, 3}) // remaining pods in the first zone
+				Expect(zau.Status.Phase).ShouldNot(Equal(opsv1.ZoneAwareUpdateAwaitingApproval))
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[0]))
+			})
+		})
+
+		Context("When MinStepInterval is set", func() {
+			It("It should not delete pods until the updated pods are ready for MinStepInterval", func() {
+				ss, zau, pods := createResources("zau-test26", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.MinStepInterval = &metav1.Duration{Duration: time.Hour}
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				pods[6].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				testUtils.UpdatePod(pods[6])
+				readyTime := metav1.NewTime(time.Now().Truncate(time.Second))
+				pods[6].Status.Conditions[0].LastTransitionTime = readyTime
+				Expect(k8sClient.Status().Update(ctx, pods[6])).Should(Succeed())
+				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+				Expect(*recheckTime).Should(BeTemporally("==", readyTime.Add(time.Hour)))
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(1)))
+			})
+
+			It("It should honor the statefulset minReadySeconds", func() {
+				ss, zau, pods := createResources("zau-test27", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				ss.Spec.MinReadySeconds = 60
+				zau.Spec.MinStepInterval = &metav1.Duration{Duration: time.Second}
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				pods[6].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				testUtils.UpdatePod(pods[6])
+				readyTime := metav1.NewTime(time.Now().Add(-30 * time.Second).Truncate(time.Second))
+				pods[6].Status.Conditions[0].LastTransitionTime = readyTime
+				Expect(k8sClient.Status().Update(ctx, pods[6])).Should(Succeed())
+				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+				Expect(*recheckTime).Should(BeTemporally("==", readyTime.Add(time.Minute)))
+
+				assertHaveNoDeletions(pods)
+			})
+		})
+
 		Context("When there is a pod getting terminated", func() {
 			It("It should not proceed to delete other pods", func() {
 				ss, zau, pods := createResources("zau-test3", replicas, maxUnavailable, zones)
@@ -171,9 +425,9 @@ var _ = Describe("ZAU Controller", func() {
 				// delete last pod
 				deletedPod := testUtils.DeletePod(pods[len(pods)-1])
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				for i := range pods {
 					pod := testUtils.GetPod(pods[i].Name)
@@ -200,9 +454,9 @@ var _ = Describe("ZAU Controller", func() {
 					testUtils.UpdatePod(pod)
 				}
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				expectNoDeletions(zau, pods)
 				Expect(len(zau.Status.OldReplicas)).Should(Equal(0))
@@ -218,9 +472,9 @@ var _ = Describe("ZAU Controller", func() {
 				nonReadyPod.Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
 				nonReadyPod = testUtils.UpdatePod(nonReadyPod)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				for i := range pods {
 					pod := testUtils.GetPod(pods[i].Name)
@@ -234,6 +488,158 @@ var _ = Describe("ZAU Controller", func() {
 			})
 		})
 
+		Context("When ProgressDeadlineSeconds is set", func() {
+			It("It should set the Failed condition once pods in the new revision are not ready after the deadline", func() {
+				ss, zau, pods := createResources("zau-test36", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				progressDeadlineSeconds := int32(60)
+				zau.Spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+
+				nonReadyPod := pods[4]
+				nonReadyPod.Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				nonReadyPod = testUtils.UpdatePod(nonReadyPod)
+				nonReadyPod.Status = v1.PodStatus{
+					Phase: v1.PodRunning,
+					Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, nonReadyPod)).Should(Succeed())
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectNoDeletions(zau, pods)
+				condition := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)
+				Expect(condition).ShouldNot(BeNil())
+				Expect(condition.Status).Should(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).Should(Equal(opsv1.ProgressDeadlineExceededReason))
+				Expect(condition.Message).Should(ContainSubstring(nonReadyPod.Name))
+				Expect(condition.Message).Should(ContainSubstring(zones[1]))
+			})
+
+			It("It should check again when the deadline is exceeded", func() {
+				ss, zau, pods := createResources("zau-test37", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				progressDeadlineSeconds := int32(3600)
+				zau.Spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+
+				nonReadyPod := testUtils.UpdatePodStatus(pods[4], v1.PodPending, v1.ContainersReady)
+				nonReadyPod.Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				testUtils.UpdatePod(nonReadyPod)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+				Expect(recheckTime.After(time.Now().Add(59 * time.Minute))).Should(BeTrue())
+
+				expectNoDeletions(zau, pods)
+				Expect(meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)).Should(BeNil())
+			})
+
+			It("It should roll back the statefulset once the deadline is exceeded when RollbackOnFailure is set", func() {
+				label := "zau-test68"
+				ss, zau, pods := createResources(label, replicas, maxUnavailable, zones)
+				cr := testUtils.CreateControllerRevision(ss, utils.ControllerKindSS, label, "current", 1)
+				cr.Data.Raw = []byte(`{"spec":{"template":{"metadata":{"annotations":{"revision":"current"}}}}}`)
+				Expect(k8sClient.Update(ctx, cr)).Should(Succeed())
+				ss.Status.CurrentRevision = cr.Name
+				ss = testUtils.UpdateStatefulSetStatus(ss)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				progressDeadlineSeconds := int32(60)
+				zau.Spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+				zau.Spec.RollbackOnFailure = true
+
+				nonReadyPod := pods[4]
+				nonReadyPod.Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				nonReadyPod = testUtils.UpdatePod(nonReadyPod)
+				nonReadyPod.Status = v1.PodStatus{
+					Phase: v1.PodRunning,
+					Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, nonReadyPod)).Should(Succeed())
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(testUtils.GetStatefulSet(ss.Name).Spec.Template.Annotations).Should(HaveKeyWithValue("revision", "current"))
+				Expect(zau.Status.Rollback).ShouldNot(BeNil())
+				Expect(zau.Status.Rollback.FromRevision).Should(Equal("update"))
+				Expect(zau.Status.Rollback.ToRevision).Should(Equal(cr.Name))
+				condition := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)
+				Expect(condition).ShouldNot(BeNil())
+				Expect(condition.Status).Should(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).Should(Equal(opsv1.ProgressDeadlineExceededReason))
+				expectEvents(recorder, opsv1.ProgressDeadlineExceededReason, "RolledBack")
+			})
+
+			It("It should not fail the rollout while it's paused manually", func() {
+				ss, zau, pods := createResources("zau-test74", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				progressDeadlineSeconds := int32(60)
+				zau.Spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+				zau.Spec.RollbackOnFailure = true
+				zau.Spec.Paused = true
+
+				nonReadyPod := pods[4]
+				nonReadyPod.Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				nonReadyPod = testUtils.UpdatePod(nonReadyPod)
+				nonReadyPod.Status = v1.PodStatus{
+					Phase: v1.PodRunning,
+					Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, nonReadyPod)).Should(Succeed())
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonManual))
+				Expect(zau.Status.Rollback).Should(BeNil())
+				Expect(meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)).Should(BeNil())
+			})
+
+			It("It should restart the deadline when the rollout is resumed", func() {
+				ss, zau, pods := createResources("zau-test75", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				nonReadyPod := pods[4]
+				nonReadyPod.Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				nonReadyPod = testUtils.UpdatePod(nonReadyPod)
+				nonReadyPod.Status = v1.PodStatus{
+					Phase: v1.PodRunning,
+					Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, nonReadyPod)).Should(Succeed())
+				zau.Status.PausedRollout = true
+				zau.Status.PauseReason = opsv1.PauseReasonManual
+				testUtils.UpdateZauStep(zau, 1, ss.Status.UpdateRevision)
+				progressDeadlineSeconds := int32(60)
+				zau.Spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+				Expect(*recheckTime).Should(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.PauseReason).Should(BeEmpty())
+				Expect(zau.Status.ResumedTime).ShouldNot(BeNil())
+				Expect(meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)).Should(BeNil())
+			})
+		})
+
 		Context("When there is non ready pods in the old revision in multiple zones", func() {
 			It("It should not proceed to delete other pods", func() {
 				ss, zau, pods := createResources("zau-test6", replicas, maxUnavailable, zones)
@@ -242,9 +648,9 @@ var _ = Describe("ZAU Controller", func() {
 				firstNonReadyPod := testUtils.UpdatePodStatus(pods[4], v1.PodPending, v1.ContainersReady)
 				secondNonReadyPod := testUtils.UpdatePodStatus(pods[2], v1.PodPending, v1.ContainersReady)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				for i := range pods {
 					pod := testUtils.GetPod(pods[i].Name)
@@ -266,9 +672,9 @@ var _ = Describe("ZAU Controller", func() {
 				firstNonReadyPod := testUtils.UpdatePodStatus(pods[4], v1.PodPending, v1.ContainersReady)
 				secondNonReadyPod := testUtils.UpdatePodStatus(pods[7], v1.PodPending, v1.ContainersReady)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				for i := range pods {
 					pod := testUtils.GetPod(pods[i].Name)
@@ -290,9 +696,9 @@ var _ = Describe("ZAU Controller", func() {
 				nonReadyPod := 0
 				testUtils.UpdatePodStatus(pods[nonReadyPod], v1.PodPending, v1.ContainersReady)
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				assertContainDeletions(pods, []int{nonReadyPod})
 
@@ -301,14 +707,30 @@ var _ = Describe("ZAU Controller", func() {
 			})
 		})
 
+		Context("When a zone order is specified", func() {
+			It("It should delete initially a single pod in the first zone of the zone order", func() {
+				ss, zau, pods := createResources("zau-test40", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.ZoneOrder = []string{zones[2], zones[0]}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				// zone-3: [pod-2, pod-5, pod-8]
+				assertContainDeletions(pods, []int{8})
+				Expect(zau.Status.ZoneOrder).Should(Equal([]string{zones[2], zones[0], zones[1]}))
+			})
+		})
+
 		Context("When dryRun is enabled", func() {
 			It("It should update zau status but not delete pods", func() {
 				ss, zau, pods := createResources("zau-test9", replicas, maxUnavailable, zones)
 				zau.Spec.DryRun = true
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				assertHaveNoDeletions(pods)
 
@@ -323,9 +745,9 @@ var _ = Describe("ZAU Controller", func() {
 				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
 				testUtils.UpdateZauStep(zau, 3, "oldRev")
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				expectLastPodInFirstZoneToBeDeleted(zau, pods)
 			})
@@ -339,9 +761,9 @@ var _ = Describe("ZAU Controller", func() {
 
 				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueOk, err: nil}
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				expectLastPodInFirstZoneToBeDeleted(zau, pods)
 			})
@@ -355,14 +777,41 @@ var _ = Describe("ZAU Controller", func() {
 
 				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueAlarm, err: nil}
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeTrue())
+				Expect(recheckTime).ShouldNot(BeNil())
 
 				expectNoDeletions(zau, pods)
 				for zone := range zau.Status.OldReplicas {
 					Expect(zau.Status.OldReplicas[zone]).Should(Equal(int32(3)))
 				}
+				Expect(zau.Status.PausedRollout).Should(BeTrue())
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonAlarm))
+				Expect(zau.Status.PausingAlarms).Should(Equal([]string{"anyAlarm"}))
+				Expect(zau.Status.PausedTime).ShouldNot(BeNil())
+				expectEvents(recorder, "PausedByAlarm")
+			})
+
+			It("It should report the alarms in alarm among the PauseRolloutAlarms", func() {
+				ss, zau, pods := createResources("zau-test42", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarm = "compositeAlarm"
+				zau.Spec.PauseRolloutAlarms = []string{"metricAlarm1", "metricAlarm2", "compositeAlarm"}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{
+					state:  types.StateValueOk,
+					states: map[string]types.StateValue{"metricAlarm2": types.StateValueAlarm},
+				}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonAlarm))
+				Expect(zau.Status.PausingAlarms).Should(Equal([]string{"metricAlarm2"}))
+				Expect(meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionPaused).Message).
+					Should(ContainSubstring("metricAlarm2"))
 			})
 
 			It("It should not pause the rollout when IgnoreAlarm is true", func() {
@@ -373,47 +822,1002 @@ var _ = Describe("ZAU Controller", func() {
 
 				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueAlarm, err: nil}
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
 				Expect(err).Should(BeNil())
-				Expect(recheck).Should(BeFalse())
+				Expect(recheckTime).Should(BeNil())
 
 				expectLastPodInFirstZoneToBeDeleted(zau, pods)
 			})
 		})
 
-		Context("When fail to get PauseRolloutAlarm state", func() {
+		Context("When a zonal pause alarm is in alarm", func() {
+			It("It should pause the rollout when the alarm is for the zone being updated", func() {
+				ss, zau, pods := createResources("zau-test45", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarm = "global"
+				zau.Spec.PauseRolloutAlarms = []string{"canary-{zone}"}
+				zau.Spec.ZonePauseRolloutAlarms = map[string]string{"us-east-1a": "health-1a", "us-east-1b": "health-1b"}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{
+					state: types.StateValueOk,
+					states: map[string]types.StateValue{
+						"canary-us-east-1a": types.StateValueAlarm,
+						"health-1a":         types.StateValueAlarm,
+					},
+				}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.PausingAlarms).Should(Equal([]string{"canary-us-east-1a", "health-1a"}))
+			})
+
+			It("It should not pause the rollout when the alarm is for another zone", func() {
+				ss, zau, pods := createResources("zau-test46", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarms = []string{"canary-{zone}"}
+				zau.Spec.ZonePauseRolloutAlarms = map[string]string{"us-east-1b": "health-1b"}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{
+					state: types.StateValueOk,
+					states: map[string]types.StateValue{
+						"canary-us-east-1b": types.StateValueAlarm,
+						"health-1b":         types.StateValueAlarm,
+					},
+				}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+			})
+		})
+
+		Context("When a PauseRolloutAlarm has insufficient data", func() {
+			It("It should not pause the rollout by default", func() {
+				ss, zau, pods := createResources("zau-test43", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarms = []string{"anyAlarm"}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueInsufficientData}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+			})
+
+			It("It should pause the rollout when PauseOnInsufficientData is true", func() {
+				ss, zau, pods := createResources("zau-test44", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarms = []string{"anyAlarm"}
+				zau.Spec.PauseOnInsufficientData = true
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueInsufficientData}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.PausingAlarms).Should(Equal([]string{"anyAlarm"}))
+			})
+		})
+
+		Context("When PauseRolloutQuery is breaching", func() {
 			It("It should pause the rollout", func() {
-				ss, zau, pods := createResources("zau-test33", replicas, maxUnavailable, zones)
+				ss, zau, pods := createResources("zau-test38", replicas, maxUnavailable, zones)
 				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
-				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+				zau.Spec.PauseRolloutQuery = &opsv1.PrometheusQuery{Query: "error_rate", Threshold: "0.1"}
 
-				controller.AlarmStateProvider = &mockAlarmStateProvider{state: "", err: fmt.Errorf("anyError")}
+				controller.QueryStateProvider = &mockQueryStateProvider{state: types.StateValueAlarm, err: nil}
 
-				recheck, err := controller.updateStatefulSet(context.TODO(), zau, ss)
-				Expect(err).ShouldNot(BeNil())
-				Expect(recheck).Should(BeFalse())
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
 
 				expectNoDeletions(zau, pods)
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonAlarm))
+				Expect(zau.Status.PausingAlarms).Should(Equal([]string{"PauseRolloutQuery"}))
+				expectEvents(recorder, "PausedByAlarm")
+			})
+
+			It("It should pause the rollout when no Prometheus address is configured", func() {
+				ss, zau, pods := createResources("zau-test39", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutQuery = &opsv1.PrometheusQuery{Query: "error_rate", Threshold: "0.1"}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonAlarmFailure))
+				Expect(meta.IsStatusConditionTrue(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable)).Should(BeTrue())
 			})
 		})
-	})
 
-	Describe("maxPodsToDelete", func() {
-		tests := []struct {
-			name              string
-			maxUnavailable    int
-			updateStep        int32
-			exponentialFactor string
-			result            int
-		}{
-			{
-				name:              "step 0",
-				maxUnavailable:    10,
-				updateStep:        0,
-				exponentialFactor: "2.0",
-				result:            1,
-			},
-			{
+		Context("When a HealthGate is set", func() {
+			var server *httptest.Server
+			var statusCode int
+			var requests []utils.HealthGateRequest
+
+			BeforeEach(func() {
+				requests = nil
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					request := utils.HealthGateRequest{}
+					Expect(json.NewDecoder(r.Body).Decode(&request)).Should(Succeed())
+					requests = append(requests, request)
+					w.WriteHeader(statusCode)
+				}))
+				controller.HealthGateChecker = &utils.HTTPHealthGateChecker{}
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("It should proceed with the step on 2xx responses", func() {
+				ss, zau, pods := createResources("zau-test47", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.HealthGate = &opsv1.HealthGate{URL: server.URL}
+				statusCode = http.StatusOK
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				Expect(requests).Should(Equal([]utils.HealthGateRequest{{
+					Namespace: zau.Namespace,
+					Name:      zau.Name,
+					Zone:      "us-east-1a",
+					Step:      1,
+					Revision:  ss.Status.UpdateRevision,
+				}}))
+			})
+
+			It("It should pause the rollout on 409 responses", func() {
+				ss, zau, pods := createResources("zau-test48", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.HealthGate = &opsv1.HealthGate{URL: server.URL}
+				statusCode = http.StatusConflict
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdatePaused))
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonHealthGate))
+				expectEvents(recorder, "PausedByHealthGate")
+			})
+
+			It("It should retry on 5xx responses with the Retry policy", func() {
+				ss, zau, pods := createResources("zau-test49", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.HealthGate = &opsv1.HealthGate{URL: server.URL, FailurePolicy: opsv1.HealthGateRetry}
+				statusCode = http.StatusInternalServerError
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).ShouldNot(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)).Should(BeNil())
+			})
+
+			It("It should fail the rollout on 5xx responses with the Fail policy", func() {
+				ss, zau, pods := createResources("zau-test50", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.HealthGate = &opsv1.HealthGate{URL: server.URL, FailurePolicy: opsv1.HealthGateFail}
+				statusCode = http.StatusServiceUnavailable
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				failed := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed)
+				Expect(failed).ShouldNot(BeNil())
+				Expect(failed.Reason).Should(Equal(opsv1.HealthGateFailedReason))
+				expectEvents(recorder, opsv1.HealthGateFailedReason)
+			})
+		})
+
+		Context("When the rollout is paused", func() {
+			It("It should not delete pods", func() {
+				ss, zau, pods := createResources("zau-test34", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.Paused = true
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdatePaused))
+				Expect(zau.Status.PausedRollout).Should(BeTrue())
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonManual))
+				Expect(zau.Status.PausedTime).ShouldNot(BeNil())
+				Expect(meta.IsStatusConditionTrue(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionPaused)).Should(BeTrue())
+				Expect(meta.IsStatusConditionFalse(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionProgressing)).Should(BeTrue())
+				expectEvents(recorder, "Paused")
+			})
+
+			It("It should resume the rollout once unpaused", func() {
+				ss, zau, pods := createResources("zau-test35", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				pausedTime := metav1.Now()
+				zau.Status.PausedRollout = true
+				zau.Status.PauseReason = opsv1.PauseReasonManual
+				zau.Status.PausedTime = &pausedTime
+				testUtils.UpdateZauStep(zau, 0, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateProgressing))
+				Expect(zau.Status.PausedRollout).Should(BeFalse())
+				Expect(zau.Status.PauseReason).Should(BeEmpty())
+				Expect(zau.Status.PausedTime).Should(BeNil())
+			})
+		})
+
+		Context("When fail to get PauseRolloutAlarm state", func() {
+			It("It should pause the rollout", func() {
+				ss, zau, pods := createResources("zau-test33", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: "", err: fmt.Errorf("anyError")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdatePaused))
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonAlarmFailure))
+				condition := meta.FindStatusCondition(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable)
+				Expect(condition).ShouldNot(BeNil())
+				Expect(condition.Status).Should(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).Should(ContainSubstring("anyAlarm: anyError"))
+				expectEvents(recorder, "AlarmsUnavailable", "PausedByAlarmFailure")
+			})
+
+			It("It should proceed with the rollout with the Proceed AlarmFailurePolicy", func() {
+				ss, zau, pods := createResources("zau-test51", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+				zau.Spec.AlarmFailurePolicy = opsv1.AlarmFailureProceed
+				zau.Spec.MaxAlarmOutageDuration = &metav1.Duration{Duration: time.Hour}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: "", err: fmt.Errorf("anyError")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				Expect(meta.IsStatusConditionTrue(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable)).Should(BeTrue())
+			})
+
+			It("It should pause the rollout once the MaxAlarmOutageDuration is exceeded", func() {
+				ss, zau, pods := createResources("zau-test52", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+				zau.Spec.AlarmFailurePolicy = opsv1.AlarmFailureProceed
+				zau.Spec.MaxAlarmOutageDuration = &metav1.Duration{Duration: time.Hour}
+				zau.Status.Conditions = []metav1.Condition{{
+					Type:               opsv1.ZoneAwareUpdateConditionAlarmsUnavailable,
+					Status:             metav1.ConditionTrue,
+					Reason:             "AlarmBackendError",
+					Message:            "previousError",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				}}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: "", err: fmt.Errorf("anyError")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.PauseReason).Should(Equal(opsv1.PauseReasonAlarmFailure))
+			})
+
+			It("It should clear the AlarmsUnavailable condition once the alarms are available", func() {
+				ss, zau, pods := createResources("zau-test53", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+				zau.Status.Conditions = []metav1.Condition{{
+					Type:               opsv1.ZoneAwareUpdateConditionAlarmsUnavailable,
+					Status:             metav1.ConditionTrue,
+					Reason:             "AlarmBackendError",
+					Message:            "previousError",
+					LastTransitionTime: metav1.Now(),
+				}}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueOk, err: nil}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				Expect(meta.IsStatusConditionFalse(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAlarmsUnavailable)).Should(BeTrue())
+				expectEvents(recorder, "AlarmsAvailable")
+			})
+		})
+
+		Context("When steps are set", func() {
+			It("It should update the number of pods of the first step", func() {
+				ss, zau, pods := createResources("zau-test57", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.Steps = []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("100%")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				Expect(zau.Status.StepIndex).Should(Equal(int32(0)))
+				Expect(zau.Status.PlannedStepSize).Should(Equal(int32(1)))
+			})
+
+			It("It should repeat the last step, capped by maxUnavailable", func() {
+				ss, zau, pods := createResources("zau-test58", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				pods[6].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+				testUtils.UpdatePod(pods[6])
+				zau.Status.UpdatingZone = zones[0]
+				zau.Status.StepIndex = 1
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+				zau.Spec.Steps = []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("100%")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{0, 3})
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(3)))
+				Expect(zau.Status.StepIndex).Should(Equal(int32(1)))
+				Expect(zau.Status.PlannedStepSize).Should(Equal(int32(maxUnavailable)))
+			})
+
+			It("It should restart the steps in a new zone", func() {
+				ss, zau, pods := createResources("zau-test59", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				// zone-1 is fully updated: [pod-0, pod-3, pod-6]
+				for _, i := range []int{0, 3, 6} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				zau.Status.UpdatingZone = zones[0]
+				zau.Status.StepIndex = 1
+				testUtils.UpdateZauStep(zau, 3, ss.Status.UpdateRevision)
+				zau.Spec.Steps = []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("100%")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{7}) // last pod in the second zone
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[1]))
+				Expect(zau.Status.StepIndex).Should(Equal(int32(0)))
+				Expect(zau.Status.PlannedStepSize).Should(Equal(int32(1)))
+			})
+		})
+
+		Context("When the deletion method is Evict", func() {
+			It("It should evict the pods", func() {
+				ss, zau, pods := createResources("zau-test55", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.DeletionMethod = opsv1.DeletionMethodEvict
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+				Expect(zau.Status.BlockedPods).Should(BeEmpty())
+			})
+
+			It("It should back off and record the blocked pods when a PodDisruptionBudget blocks the eviction", func() {
+				label := "zau-test56"
+				ss, zau, pods := createResources(label, replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.DeletionMethod = opsv1.DeletionMethodEvict
+
+				pdbMaxUnavailable := intstr.FromInt(0)
+				pdb := &policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{Name: label + "-pdb", Namespace: metav1.NamespaceDefault},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						MaxUnavailable: &pdbMaxUnavailable,
+						Selector:       ss.Spec.Selector,
+					},
+				}
+				Expect(k8sClient.Create(ctx, pdb)).Should(Succeed())
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				expectNoDeletions(zau, pods)
+				Expect(zau.Status.BlockedPods).Should(HaveLen(1))
+				Expect(zau.Status.BlockedPods[0].Name).Should(Equal(pods[6].Name))
+				Expect(zau.Status.BlockedPods[0].Budget).Should(Equal("PodDisruptionBudget " + pdb.Name))
+				Expect(zau.Status.BlockedPods[0].Attempts).Should(Equal(int32(1)))
+				expectEvents(recorder, "EvictionBlocked")
+
+				// The spec is reset by the status update
+				zau.Spec.DeletionMethod = opsv1.DeletionMethodEvict
+				_, err = controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(zau.Status.BlockedPods[0].Attempts).Should(Equal(int32(2)))
+			})
+		})
+
+		Context("When rolling back", func() {
+			It("It should replace the most recently updated pods first", func() {
+				ss, zau, pods := createResources("zau-test67", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				// The faulty revision updated zone-1 and pods 7 and 4 in zone-2, the other pods are
+				// already in the revision rolled back to
+				for _, i := range []int{1, 2, 5, 8} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				zau.Status.Rollback = &opsv1.RollbackStatus{FromRevision: "faulty", ToRevision: ss.Status.UpdateRevision, Time: metav1.Now()}
+				testUtils.UpdateZauStep(zau, 0, ss.Status.UpdateRevision)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{4}) // last pod updated in zone-2
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[1]))
+				Expect(zau.Status.ZoneOrder).Should(Equal(zones))
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateRollingBack))
+			})
+		})
+
+		Context("When a canary is set", func() {
+			It("It should delete a canary pod in each zone, up to maxUnavailable", func() {
+				ss, zau, pods := createResources("zau-test60", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.Canary = &opsv1.Canary{}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{6, 7}) // last pod in the first 2 zones
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateCanary))
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(1)))
+				Expect(zau.Status.DeletedReplicas).Should(Equal(int32(2)))
+				Expect(zau.Status.UpdatingZone).Should(BeEmpty())
+				expectEvents(recorder, "CanaryDeleted")
+			})
+
+			It("It should complete the canary and update the first zone once all zones have a canary pod", func() {
+				ss, zau, pods := createResources("zau-test61", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				for _, i := range []int{6, 7, 8} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+				zau.Spec.Canary = &opsv1.Canary{}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{3}) // last old pod in the first zone
+				Expect(zau.Status.CanaryRevision).Should(Equal(ss.Status.UpdateRevision))
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(1)))
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[0]))
+				expectEvents(recorder, "CanaryCompleted")
+			})
+
+			It("It should not update the first zone before the canary bake ends", func() {
+				ss, zau, pods := createResources("zau-test62", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				for _, i := range []int{6, 7, 8} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+				zau.Spec.Canary = &opsv1.Canary{BakeDuration: &metav1.Duration{Duration: time.Hour}}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.CanaryRevision).Should(BeEmpty())
+				Expect(zau.Status.CanaryBakeEndTime).ShouldNot(BeNil())
+				Expect(zau.Status.CanaryBakeEndTime.Time).Should(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+				expectEvents(recorder, "CanaryBaking")
+			})
+
+			It("It should pause the canary when a zonal pause alarm is in alarm for a canary zone", func() {
+				ss, zau, pods := createResources("zau-test70", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.Canary = &opsv1.Canary{}
+				zau.Spec.ZonePauseRolloutAlarms = map[string]string{"us-east-1b": "health-1b"}
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{
+					state:  types.StateValueOk,
+					states: map[string]types.StateValue{"health-1b": types.StateValueAlarm},
+				}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).ShouldNot(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.PausingAlarms).Should(Equal([]string{"health-1b"}))
+			})
+
+			It("It should skip the canary when dryRun is enabled", func() {
+				ss, zau, pods := createResources("zau-test71", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.Canary = &opsv1.Canary{}
+				zau.Spec.DryRun = true
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertHaveNoDeletions(pods)
+				Expect(zau.Status.Phase).ShouldNot(Equal(opsv1.ZoneAwareUpdateCanary))
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[0]))
+				Expect(zau.Status.DeletedReplicas).Should(Equal(int32(1)))
+			})
+		})
+
+		Context("When MaxConcurrentZones is set", func() {
+			It("It should update the first zones at the same time", func() {
+				ss, zau, pods := createResources("zau-test63", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.MaxConcurrentZones = 2
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{6, 7}) // last pod in the first 2 zones
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(1)))
+				Expect(zau.Status.DeletedReplicas).Should(Equal(int32(2)))
+				Expect(zau.Status.UpdatingZone).Should(Equal(zones[0]))
+				Expect(zau.Status.UpdatingZones).Should(Equal(zones[:2]))
+			})
+
+			It("It should not delete more than maxUnavailable pods across zones", func() {
+				ss, zau, pods := createResources("zau-test64", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				testUtils.UpdateZauStep(zau, 2, ss.Status.UpdateRevision)
+				zau.Spec.MaxConcurrentZones = 3
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{3, 6}) // last 2 pods in the first zone
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(3)))
+				Expect(zau.Status.DeletedReplicas).Should(Equal(int32(maxUnavailable)))
+				Expect(zau.Status.UpdatingZones).Should(Equal(zones))
+			})
+
+			It("It should delete the non ready pods in the zones being updated", func() {
+				ss, zau, pods := createResources("zau-test65", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.MaxConcurrentZones = 2
+
+				testUtils.UpdatePodStatus(pods[0], v1.PodPending, v1.ContainersReady)
+				testUtils.UpdatePodStatus(pods[4], v1.PodPending, v1.ContainersReady)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{0, 4})
+				Expect(zau.Status.DeletedReplicas).Should(Equal(int32(2)))
+			})
+
+			It("It should not proceed when there are non ready pods outside of the zones being updated", func() {
+				ss, zau, pods := createResources("zau-test66", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				zau.Spec.MaxConcurrentZones = 2
+
+				testUtils.UpdatePodStatus(pods[8], v1.PodPending, v1.ContainersReady)
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectNoDeletions(zau, pods)
+			})
+
+			It("It should keep updating the other zones of the batch, without baking, when a zone is updated", func() {
+				ss, zau, pods := createResources("zau-test72", replicas, maxUnavailable, zones)
+				ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+
+				// zone-1 is fully updated: [pod-0, pod-3, pod-6], zone-2 is in progress: [pod-1, pod-4, pod-7]
+				for _, i := range []int{0, 3, 6, 7} {
+					pods[i].Labels[apps.ControllerRevisionHashLabelKey] = ss.Status.UpdateRevision
+					testUtils.UpdatePod(pods[i])
+				}
+				zau.Status.UpdatingZone = zones[0]
+				zau.Status.UpdatingZones = zones[:2]
+				zau.Status.StepIndex = 1
+				testUtils.UpdateZauStep(zau, 3, ss.Status.UpdateRevision)
+				zau.Spec.MaxConcurrentZones = 2
+				zau.Spec.ZoneBakeDuration = &metav1.Duration{Duration: time.Hour}
+				zau.Spec.Steps = []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("100%")}
+
+				recheckTime, err := controller.updateStatefulSet(context.TODO(), zau, ss)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(pods, []int{1, 4}) // remaining pods in the second zone, none in the third zone
+				Expect(zau.Status.NextZoneEligibleTime).Should(BeNil())
+				Expect(zau.Status.UpdatingZones).Should(Equal([]string{zones[1]}))
+				Expect(zau.Status.StepIndex).Should(Equal(int32(1)))
+			})
+		})
+	})
+
+	Describe("updateWorkload", func() {
+		Context("When the workload is a paused deployment", func() {
+			It("It should move a single pod in the first zone to the new ReplicaSet", func() {
+				label := "zau-deploy1"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				pods := []*v1.Pod{}
+				for i := 0; i < replicas; {
+					for _, zone := range zones {
+						pods = append(pods, testUtils.CreateReplicaSetPod(podName(label, i), zone, v1.PodRunning, label, oldRS))
+						i++
+					}
+				}
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(BeNil())
+				Expect(w).ShouldNot(BeNil())
+				Expect(w.UpdateRevision()).ShouldNot(Equal(oldRS.Labels[apps.DefaultDeploymentUniqueLabelKey]))
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				Expect(*testUtils.GetReplicaSet(oldRS.Name).Spec.Replicas).Should(Equal(int32(replicas - 1)))
+				newRS := testUtils.GetReplicaSet(deployment.Name + "-" + w.UpdateRevision())
+				Expect(*newRS.Spec.Replicas).Should(Equal(int32(1)))
+
+				// zone-1: [pod-0, pod-3, pod-6]
+				for i := range pods {
+					pod := testUtils.GetPod(pods[i].Name)
+					_, found := pod.Annotations[v1.PodDeletionCost]
+					Expect(found).Should(Equal(i == 6), "Unexpected deletion cost for pod # %d", i)
+				}
+
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(1)))
+				Expect(zau.Status.DeletedReplicas).Should(Equal(int32(1)))
+				Expect(zau.Status.CurrentRevision).Should(Equal(oldRS.Labels[apps.DefaultDeploymentUniqueLabelKey]))
+			})
+		})
+
+		Context("When the deployment is not paused", func() {
+			It("It should not replace pods", func() {
+				label := "zau-deploy2"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				deployment.Spec.Paused = false
+				Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(BeNil())
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				Expect(*testUtils.GetReplicaSet(oldRS.Name).Spec.Replicas).Should(Equal(int32(replicas)))
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(0)))
+			})
+		})
+
+		Context("When the deployment uses the RollingUpdate strategy", func() {
+			It("It should not replace pods", func() {
+				label := "zau-deploy5"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				deployment.Spec.Strategy = apps.DeploymentStrategy{Type: apps.RollingUpdateDeploymentStrategyType}
+				Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(BeNil())
+				Expect(w.UpdateStrategyReady()).Should(BeFalse())
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				Expect(*testUtils.GetReplicaSet(oldRS.Name).Spec.Replicas).Should(Equal(int32(replicas)))
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(0)))
+			})
+		})
+
+		Context("When the deployment controller scales the ReplicaSets", func() {
+			It("It should not change the replicas moved to the new ReplicaSet with the Recreate strategy", func() {
+				label := "zau-deploy6"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				oldRS.Annotations = map[string]string{utils.RevisionAnnotation: "3"}
+				Expect(k8sClient.Update(ctx, oldRS)).Should(Succeed())
+				pods := []*v1.Pod{}
+				for i := 0; i < replicas; {
+					for _, zone := range zones {
+						pods = append(pods, testUtils.CreateReplicaSetPod(podName(label, i), zone, v1.PodRunning, label, oldRS))
+						i++
+					}
+				}
+
+				scaleClient := &deploymentScaleClient{Client: k8sClient, deployment: deployment}
+				w, err := newDeploymentWorkload(context.TODO(), scaleClient, deployment)
+				Expect(err).Should(BeNil())
+				Expect(w.UpdateStrategyReady()).Should(BeTrue())
+				Expect(w.ReplacePods(context.TODO(), []*v1.Pod{pods[6], pods[3]})).Should(Succeed())
+
+				Expect(scaleClient.scales).Should(BeEmpty())
+				Expect(*testUtils.GetReplicaSet(oldRS.Name).Spec.Replicas).Should(Equal(int32(replicas - 2)))
+				newRS := testUtils.GetReplicaSet(deployment.Name + "-" + w.UpdateRevision())
+				Expect(*newRS.Spec.Replicas).Should(Equal(int32(2)))
+				Expect(newRS.Annotations).Should(Equal(map[string]string{
+					utils.RevisionAnnotation:        "4",
+					utils.DesiredReplicasAnnotation: strconv.Itoa(replicas),
+					utils.MaxReplicasAnnotation:     strconv.Itoa(replicas),
+				}))
+			})
+
+			It("It should spread the max surge across the ReplicaSets with the RollingUpdate strategy", func() {
+				label := "zau-deploy7"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				deployment.Spec.Strategy = apps.DeploymentStrategy{Type: apps.RollingUpdateDeploymentStrategyType}
+				Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				pods := []*v1.Pod{}
+				for i := 0; i < replicas; {
+					for _, zone := range zones {
+						pods = append(pods, testUtils.CreateReplicaSetPod(podName(label, i), zone, v1.PodRunning, label, oldRS))
+						i++
+					}
+				}
+
+				scaleClient := &deploymentScaleClient{Client: k8sClient, deployment: deployment}
+				w, err := newDeploymentWorkload(context.TODO(), scaleClient, deployment)
+				Expect(err).Should(BeNil())
+				Expect(w.ReplacePods(context.TODO(), []*v1.Pod{pods[6]})).Should(Succeed())
+
+				Expect(scaleClient.scales).ShouldNot(BeEmpty())
+			})
+		})
+
+		Context("When PauseRolloutAlarm is in alarm and RollbackOnAlarm is set", func() {
+			It("It should roll back the deployment to the current revision", func() {
+				label := "zau-deploy3"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				pods := []*v1.Pod{}
+				for i := 0; i < replicas; {
+					for _, zone := range zones {
+						pods = append(pods, testUtils.CreateReplicaSetPod(podName(label, i), zone, v1.PodRunning, label, oldRS))
+						i++
+					}
+				}
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+				zau.Spec.RollbackOnAlarm = true
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueAlarm, err: nil}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(BeNil())
+				updateRevision := w.UpdateRevision()
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				Expect(testUtils.GetDeployment(deployment.Name).Spec.Template.Spec.Containers[0].Image).Should(Equal("old"))
+				for i := range pods {
+					_, found := testUtils.GetPod(pods[i].Name).Annotations[v1.PodDeletionCost]
+					Expect(found).Should(BeFalse())
+				}
+
+				Expect(zau.Status.Rollback).ShouldNot(BeNil())
+				Expect(zau.Status.Rollback.FromRevision).Should(Equal(updateRevision))
+				Expect(zau.Status.Rollback.ToRevision).Should(Equal(oldRS.Labels[apps.DefaultDeploymentUniqueLabelKey]))
+				expectEvents(recorder, "RolledBack")
+			})
+
+			It("It should ignore the alarm while rolling back", func() {
+				label := "zau-deploy4"
+				deployment := testUtils.CreateDeployment(int32(replicas), label, "new")
+				oldRS := testUtils.CreateReplicaSet(deployment, int32(replicas), "old")
+				for i := 0; i < replicas; {
+					for _, zone := range zones {
+						testUtils.CreateReplicaSetPod(podName(label, i), zone, v1.PodRunning, label, oldRS)
+						i++
+					}
+				}
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+				zau.Spec.PauseRolloutAlarm = "anyAlarm"
+				zau.Spec.RollbackOnAlarm = true
+
+				controller.AlarmStateProvider = &mockAlarmStateProvider{state: types.StateValueAlarm, err: nil}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(BeNil())
+				zau.Status.Rollback = &opsv1.RollbackStatus{FromRevision: "anyRevision", ToRevision: w.UpdateRevision(), Time: metav1.Now()}
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				Expect(*testUtils.GetReplicaSet(oldRS.Name).Spec.Replicas).Should(Equal(int32(replicas - 1)))
+				Expect(testUtils.GetDeployment(deployment.Name).Spec.Template.Spec.Containers[0].Image).Should(Equal("new"))
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateRollingBack))
+				Expect(zau.Status.PausedRollout).Should(BeFalse())
+			})
+		})
+	})
+
+	Describe("updateWorkload for multiple statefulsets", func() {
+		Context("When the ZAU selects multiple statefulsets", func() {
+			It("It should update the first zone across all statefulsets", func() {
+				label := "zau-multi1"
+				ssA := testUtils.CreateStatefulSet(3, label+"-a")
+				ssB := testUtils.CreateStatefulSet(3, label+"-b")
+				podsA := createPods(label+"-a", 3, zones, ssA)
+				podsB := createPods(label+"-b", 3, zones, ssB)
+				for _, ss := range []*apps.StatefulSet{ssA, ssB} {
+					ss.Status.UpdateRevision = "update"
+					testUtils.UpdateStatefulSetStatus(ss)
+					ss.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				}
+				// zone-1: [a-0, b-0]
+				podsB[0].Labels[apps.ControllerRevisionHashLabelKey] = "update"
+				testUtils.UpdatePod(podsB[0])
+
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.StatefulSets = []string{ssA.Name, ssB.Name}
+				w := newStatefulSetWorkload(k8sClient, ssB, ssA)
+				Expect(w.Name()).Should(Equal(ssA.Name + "," + ssB.Name))
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				assertContainDeletions(podsA, []int{0})
+				assertHaveNoDeletions(podsB)
+
+				Expect(zau.Status.UpdateStep).Should(Equal(int32(1)))
+				Expect(zau.Status.UpdateRevision).Should(Equal("update,update"))
+				Expect(zau.Status.OldReplicas[zones[0]]).Should(Equal(int32(1)))
+				Expect(zau.Status.OldReplicas[zones[1]]).Should(Equal(int32(2)))
+				Expect(zau.Status.OldReplicas[zones[2]]).Should(Equal(int32(2)))
+			})
+		})
+
+		Context("When the ZAU StatefulSet selector is empty", func() {
+			It("It should not select any statefulset", func() {
+				label := "zau-multi2"
+				testUtils.CreateStatefulSet(3, label)
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.StatefulSetSelector = &metav1.LabelSelector{}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(MatchError(ContainSubstring("must not be empty")))
+				Expect(w).Should(BeNil())
+			})
+		})
+	})
+
+	Describe("updateWorkload for daemonsets", func() {
+		Context("When the daemonset has pods in an old revision", func() {
+			It("It should delete a single old pod in the first zone", func() {
+				label := "zau-ds1"
+				ds := testUtils.CreateDaemonSet(label)
+				testUtils.CreateControllerRevision(ds, utils.ControllerKindDS, label, "old", 1)
+				testUtils.CreateControllerRevision(ds, utils.ControllerKindDS, label, "update", 2)
+				pods := []*v1.Pod{}
+				for i := 0; i < replicas; {
+					for _, zone := range zones {
+						pods = append(pods, testUtils.CreateDaemonSetPod(podName(label, i), zone, v1.PodRunning, label, "old", ds))
+						i++
+					}
+				}
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "DaemonSet", Name: ds.Name}
+
+				w, err := controller.getWorkload(context.TODO(), zau)
+				Expect(err).Should(BeNil())
+				Expect(w).ShouldNot(BeNil())
+				Expect(w.UpdateRevision()).Should(Equal("update"))
+				Expect(w.CurrentRevision()).Should(Equal("old"))
+
+				recheckTime, err := controller.updateWorkload(context.TODO(), zau, w)
+				Expect(err).Should(BeNil())
+				Expect(recheckTime).Should(BeNil())
+
+				expectLastPodInFirstZoneToBeDeleted(zau, pods)
+			})
+		})
+	})
+
+	Describe("statefulSetWorkload Rollback", func() {
+		Context("When the StatefulSet has a current revision", func() {
+			It("It should restore the pod template of the current revision", func() {
+				label := "zau-rollback1"
+				ss := testUtils.CreateStatefulSet(int32(replicas), label)
+				cr := testUtils.CreateControllerRevision(ss, utils.ControllerKindSS, label, "current", 1)
+				cr.Data.Raw = []byte(`{"spec":{"template":{"metadata":{"annotations":{"revision":"current"}}}}}`)
+				Expect(k8sClient.Update(ctx, cr)).Should(Succeed())
+
+				ss.Status.CurrentRevision = cr.Name
+				ss.Status.UpdateRevision = "update"
+				ss = testUtils.UpdateStatefulSetStatus(ss)
+
+				w := newStatefulSetWorkload(k8sClient, ss)
+				Expect(w.Rollback(context.TODO())).Should(Succeed())
+
+				ss = testUtils.GetStatefulSet(ss.Name)
+				Expect(ss.Spec.Template.Annotations).Should(HaveKeyWithValue("revision", "current"))
+			})
+		})
+	})
+
+	Describe("maxPodsToDelete", func() {
+		tests := []struct {
+			name              string
+			maxUnavailable    int
+			updateStep        int32
+			exponentialFactor string
+			result            int
+		}{
+			{
+				name:              "step 0",
+				maxUnavailable:    10,
+				updateStep:        0,
+				exponentialFactor: "2.0",
+				result:            1,
+			},
+			{
 				name:              "step 1",
 				maxUnavailable:    10,
 				updateStep:        1,
@@ -474,6 +1878,38 @@ var _ = Describe("ZAU Controller", func() {
 		}
 	})
 
+	Describe("maxPodsInStep", func() {
+		It("It should scale the step with the replicas and cap it by maxUnavailable", func() {
+			Expect(maxPodsInStep(10, 20, intstr.FromInt(5))).Should(Equal(5))
+			Expect(maxPodsInStep(10, 20, intstr.FromString("25%"))).Should(Equal(5))
+			Expect(maxPodsInStep(10, 20, intstr.FromString("100%"))).Should(Equal(10))
+			Expect(maxPodsInStep(10, 20, intstr.FromInt(50))).Should(Equal(10))
+
+			_, err := maxPodsInStep(10, 20, intstr.FromString("half"))
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Describe("evictionBackoff", func() {
+		It("It should double the backoff with the attempts up to the max backoff", func() {
+			Expect(evictionBackoff([]opsv1.BlockedPod{{Name: "pod-a", Attempts: 1}})).Should(Equal(minEvictionBackoff))
+			Expect(evictionBackoff([]opsv1.BlockedPod{{Name: "pod-a", Attempts: 1}, {Name: "pod-b", Attempts: 3}})).Should(Equal(4 * minEvictionBackoff))
+			Expect(evictionBackoff([]opsv1.BlockedPod{{Name: "pod-a", Attempts: 100}})).Should(Equal(maxEvictionBackoff))
+		})
+	})
+
+	Describe("evictionBlocked", func() {
+		It("It should only treat disruption budget denials as blocked evictions", func() {
+			podsResource := schema.GroupResource{Resource: "pods"}
+			Expect(evictionBlocked(apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10))).Should(BeTrue())
+			Expect(evictionBlocked(apierrors.NewForbidden(podsResource, "pod-a",
+				fmt.Errorf("denying pod eviction for pod-a by ZoneDisruptionBudget zdb-a")))).Should(BeTrue())
+			Expect(evictionBlocked(apierrors.NewForbidden(podsResource, "pod-a",
+				fmt.Errorf("User \"system:serviceaccount:default:zau\" cannot create resource \"pods/eviction\"")))).Should(BeFalse())
+			Expect(evictionBlocked(apierrors.NewInternalError(fmt.Errorf("etcd unavailable")))).Should(BeFalse())
+		})
+	})
+
 	Describe("updateZauStatus", func() {
 		Context("When pods in a single zone are in the old revision", func() {
 			It("It should reset other zones in OldReplicas", func() {
@@ -487,7 +1923,7 @@ var _ = Describe("ZAU Controller", func() {
 				oldPodsCountMap := make(map[string]int32)
 				oldPodsCountMap["zone-2"] = 1
 
-				err := controller.updateZauStatus(ctx, zau, ss, 1, 1, oldPodsCountMap, false)
+				err := controller.updateZauStatus(ctx, zau, newStatefulSetWorkload(k8sClient, ss), nil, "", 1, 1, oldPodsCountMap, "", nil)
 				Expect(err).Should(BeNil())
 
 				Expect(zau.Status.OldReplicas["zone-1"]).Should(Equal(int32(0)))
@@ -507,13 +1943,91 @@ var _ = Describe("ZAU Controller", func() {
 				oldPodsCountMap["zone-1"] = 3
 				oldPodsCountMap["zone-2"] = 1
 
-				err := controller.updateZauStatus(ctx, zau, ss, 1, 1, oldPodsCountMap, false)
+				err := controller.updateZauStatus(ctx, zau, newStatefulSetWorkload(k8sClient, ss), nil, "", 1, 1, oldPodsCountMap, "", nil)
 				Expect(err).Should(BeNil())
 
 				Expect(zau.Status.OldReplicas["zone-1"]).Should(Equal(int32(3)))
 				Expect(zau.Status.OldReplicas["zone-2"]).Should(Equal(int32(1)))
 			})
 		})
+
+		Context("When pods are in an old revision", func() {
+			It("It should set the Progressing condition", func() {
+				label := "zau-update3"
+				ss := testUtils.CreateStatefulSet(int32(replicas), label)
+				zau := testUtils.CreateZau(ss.Name, intstr.FromInt(maxUnavailable), label)
+
+				oldPodsCountMap := map[string]int32{"zone-1": 2}
+				err := controller.updateZauStatus(ctx, zau, newStatefulSetWorkload(k8sClient, ss), nil, "", 1, 1, oldPodsCountMap, "", nil)
+				Expect(err).Should(BeNil())
+
+				Expect(zau.Status.ObservedGeneration).Should(Equal(zau.Generation))
+				Expect(meta.IsStatusConditionTrue(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionProgressing)).Should(BeTrue())
+				Expect(meta.IsStatusConditionFalse(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAvailable)).Should(BeTrue())
+				Expect(meta.IsStatusConditionFalse(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionPaused)).Should(BeTrue())
+				Expect(meta.IsStatusConditionFalse(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionDegraded)).Should(BeTrue())
+			})
+		})
+
+		Context("When all pods are in the update revision", func() {
+			It("It should set the Available condition", func() {
+				label := "zau-update4"
+				ss := testUtils.CreateStatefulSet(int32(replicas), label)
+				zau := testUtils.CreateZau(ss.Name, intstr.FromInt(maxUnavailable), label)
+
+				err := controller.updateZauStatus(ctx, zau, newStatefulSetWorkload(k8sClient, ss), nil, "", 0, 0, map[string]int32{}, "", nil)
+				Expect(err).Should(BeNil())
+
+				Expect(zau.Status.Phase).Should(Equal(opsv1.ZoneAwareUpdateCompleted))
+				Expect(meta.IsStatusConditionTrue(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionAvailable)).Should(BeTrue())
+				Expect(meta.IsStatusConditionFalse(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionProgressing)).Should(BeTrue())
+			})
+		})
+	})
+
+	Describe("finalizeZau", func() {
+		Context("When the StatefulSet update strategy was switched to OnDelete", func() {
+			It("It should restore the original update strategy and remove the finalizer", func() {
+				label := "zau-test54"
+				ss := testUtils.CreateStatefulSet(int32(replicas), label)
+				Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.RollingUpdateStatefulSetStrategyType))
+				_, err := utils.ForceOnDeleteUpdateStrategy(ss)
+				Expect(err).Should(BeNil())
+				ss.Labels = map[string]string{utils.ZoneAwareUpdateLabel: label + "-zau"}
+				Expect(k8sClient.Update(ctx, ss)).Should(Succeed())
+
+				zau := testUtils.CreateZau(ss.Name, intstr.FromInt(maxUnavailable), label)
+				zau.Finalizers = []string{restoreUpdateStrategyFinalizer}
+				Expect(k8sClient.Update(ctx, zau)).Should(Succeed())
+
+				Expect(controller.finalizeZau(context.TODO(), zau)).Should(Succeed())
+
+				ss = testUtils.GetStatefulSet(ss.Name)
+				Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.RollingUpdateStatefulSetStrategyType))
+				Expect(ss.Annotations).ShouldNot(HaveKey(utils.OriginalUpdateStrategyAnnotation))
+				Expect(ss.Labels).ShouldNot(HaveKey(utils.ZoneAwareUpdateLabel))
+				Expect(zau.Finalizers).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("enforceOnDelete", func() {
+		Context("When the StatefulSet uses the RollingUpdate strategy", func() {
+			It("It should switch it to OnDelete and label it", func() {
+				label := "zau-test69"
+				ss := testUtils.CreateStatefulSet(int32(replicas), label)
+				Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.RollingUpdateStatefulSetStrategyType))
+				zau := testUtils.CreateZau(ss.Name, intstr.FromInt(maxUnavailable), label)
+
+				Expect(controller.enforceOnDelete(context.TODO(), zau, newStatefulSetWorkload(k8sClient, ss))).Should(Succeed())
+
+				ss = testUtils.GetStatefulSet(ss.Name)
+				Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.OnDeleteStatefulSetStrategyType))
+				Expect(ss.Annotations).Should(HaveKey(utils.OriginalUpdateStrategyAnnotation))
+				Expect(ss.Labels).Should(HaveKeyWithValue(utils.ZoneAwareUpdateLabel, zau.Name))
+				expectEvents(recorder, "UpdateStrategyEnforced")
+			})
+		})
 	})
 
 	Describe("findZauForPod", func() {
@@ -545,6 +2059,22 @@ var _ = Describe("ZAU Controller", func() {
 			})
 		})
 
+		Context("When the StatefulSet is selected by the ZAU label selector", func() {
+			It("It should return a reconcile request with the ZAU information", func() {
+				label := "zau-findtest3"
+				ss := testUtils.CreateStatefulSet(int32(replicas), label)
+				ss.Labels = map[string]string{"shard-group": label}
+				Expect(k8sClient.Update(ctx, ss)).Should(Succeed())
+				zau := testUtils.CreateZau("", intstr.FromInt(maxUnavailable), label)
+				zau.Spec.StatefulSetSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"shard-group": label}}
+				Expect(k8sClient.Update(ctx, zau)).Should(Succeed())
+
+				results := controller.findZauForStatefulSet(ss)
+				Expect(len(results)).Should(Equal(1))
+				Expect(results[0].Name).Should(Equal(zau.Name))
+			})
+		})
+
 		Context("When there is no ZAU associated to the StatefulSet", func() {
 			It("It should not return a reconcile request", func() {
 				ss := testUtils.CreateStatefulSet(int32(replicas), "zau-findtest21")
@@ -555,6 +2085,56 @@ var _ = Describe("ZAU Controller", func() {
 	})
 })
 
+// expectEvents checks that events with the given reasons were recorded.
+// deploymentControllerScale simulates the scale step that the deployment controller also runs for paused
+// deployments, and returns the replicas it would set on the ReplicaSets. The proportional scaling of
+// RollingUpdate deployments is simplified to scaling the new ReplicaSet by the missing surge.
+func deploymentControllerScale(deployment *apps.Deployment, newRS *apps.ReplicaSet, oldRSs []*apps.ReplicaSet) map[string]int32 {
+	replicas := *deployment.Spec.Replicas
+	var active []*apps.ReplicaSet
+	for _, rs := range append([]*apps.ReplicaSet{newRS}, oldRSs...) {
+		if rs != nil && *rs.Spec.Replicas > 0 {
+			active = append(active, rs)
+		}
+	}
+
+	// The only active ReplicaSet, or the new one if none is active, is scaled to the deployment replicas
+	if len(active) <= 1 {
+		rs := newRS
+		if len(active) == 1 {
+			rs = active[0]
+		}
+		if rs == nil || *rs.Spec.Replicas == replicas {
+			return nil
+		}
+		return map[string]int32{rs.Name: replicas}
+	}
+
+	if deployment.Spec.Strategy.Type != apps.RollingUpdateDeploymentStrategyType || newRS == nil {
+		return nil
+	}
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(deployment.Spec.Strategy.RollingUpdate.MaxSurge, int(replicas), true)
+	Expect(err).Should(BeNil())
+	total := int32(0)
+	for _, rs := range active {
+		total += *rs.Spec.Replicas
+	}
+	if delta := replicas + int32(maxSurge) - total; delta != 0 {
+		return map[string]int32{newRS.Name: *newRS.Spec.Replicas + delta}
+	}
+	return nil
+}
+
+func expectEvents(recorder *record.FakeRecorder, reasons ...string) {
+	events := []string{}
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	for _, reason := range reasons {
+		Expect(events).Should(ContainElement(ContainSubstring(" "+reason+" ")), "Missing event with reason %s", reason)
+	}
+}
+
 func expectNoDeletions(zau *opsv1.ZoneAwareUpdate, pods []*v1.Pod) {
 	assertHaveNoDeletions(pods)
 
@@ -0,0 +1,699 @@
+/*
+Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+	utils "github.com/aws/zone-aware-controllers-for-k8s/pkg/utils"
+)
+
+// workload abstracts the resources whose rollout can be coordinated by a ZAU.
+// Each kind has its own way of tracking revisions and replacing pods in the old revision.
+type workload interface {
+	// Kind returns the workload kind, e.g. StatefulSet.
+	Kind() string
+	// Name returns the workload name.
+	Name() string
+	// UpdateStrategyReady returns true if the workload is configured to have its rollout driven by the ZAU.
+	UpdateStrategyReady() bool
+	// Pods returns all pods selected by the workload.
+	Pods(ctx context.Context) ([]*v1.Pod, error)
+	// PodRevision returns the revision of the pod, and false if it can't be found.
+	PodRevision(pod *v1.Pod) (string, bool)
+	// IsUpdated returns true if the pod is in the update revision.
+	IsUpdated(pod *v1.Pod) bool
+	// CurrentRevision returns the revision used to generate the old pods.
+	CurrentRevision() string
+	// UpdateRevision returns the new revision pods should be updated to.
+	UpdateRevision() string
+	// Replicas returns the number of workload replicas.
+	Replicas() int32
+	// AllReplicasReady returns true if all workload replicas are ready.
+	AllReplicasReady() bool
+	// MinReadySeconds returns the minimum number of seconds a pod should be ready to be considered available.
+	MinReadySeconds() int32
+	// ReplacePods replaces the given pods in the old revision by pods in the update revision.
+	ReplacePods(ctx context.Context, pods []*v1.Pod) error
+	// CompleteRollout is called once all pods are in the update revision.
+	CompleteRollout(ctx context.Context) error
+	// Rollback restores the pod template of the current revision, so the pods in the update revision
+	// get replaced by the next rollout steps.
+	Rollback(ctx context.Context) error
+}
+
+const (
+	// Deletion cost set to the pods to be replaced in a Deployment rollout,
+	// so the ReplicaSet controller chooses them when scaling down the old ReplicaSets.
+	replacedPodDeletionCost = math.MinInt32
+)
+
+// getWorkload returns the workload referenced by the ZAU or nil if it doesn't exist.
+func (r *ZoneAwareUpdateReconciler) getWorkload(ctx context.Context, zau *opsv1.ZoneAwareUpdate) (workload, error) {
+	if isStatefulSetGroup(zau) {
+		return r.getStatefulSetGroup(ctx, zau)
+	}
+
+	kind, name := workloadRef(zau)
+	key := types.NamespacedName{Name: name, Namespace: zau.Namespace}
+
+	switch kind {
+	case utils.ControllerKindSS.Kind:
+		var sts apps.StatefulSet
+		if err := r.Get(ctx, key, &sts); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return newStatefulSetWorkload(r.Client, &sts), nil
+	case utils.ControllerKindDeployment.Kind:
+		var deployment apps.Deployment
+		if err := r.Get(ctx, key, &deployment); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return newDeploymentWorkload(ctx, r.Client, &deployment)
+	case utils.ControllerKindDS.Kind:
+		var ds apps.DaemonSet
+		if err := r.Get(ctx, key, &ds); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return newDaemonSetWorkload(ctx, r.Client, &ds)
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %q", kind)
+	}
+}
+
+// errEmptyStatefulSetSelector is returned for ZAUs with an empty StatefulSetSelector, which would select
+// every StatefulSet in the namespace.
+var errEmptyStatefulSetSelector = fmt.Errorf("statefulsetSelector must not be empty")
+
+// getStatefulSetGroup returns the StatefulSets selected by name or label selector, or nil if none exists.
+func (r *ZoneAwareUpdateReconciler) getStatefulSetGroup(ctx context.Context, zau *opsv1.ZoneAwareUpdate) (workload, error) {
+	statefulSets := map[string]*apps.StatefulSet{}
+
+	for _, name := range zau.Spec.StatefulSets {
+		var sts apps.StatefulSet
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: zau.Namespace}, &sts); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+			r.Logger.Info("StatefulSet not found", "zau", zau.Name, "sts", name)
+			continue
+		}
+		statefulSets[sts.Name] = &sts
+	}
+
+	if zau.Spec.StatefulSetSelector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(zau.Spec.StatefulSetSelector)
+		if err != nil {
+			return nil, err
+		}
+		// An empty selector would roll out every StatefulSet in the namespace
+		if labelSelector.Empty() {
+			return nil, errEmptyStatefulSetSelector
+		}
+		stsList := &apps.StatefulSetList{}
+		if err := r.List(ctx, stsList, &client.ListOptions{Namespace: zau.Namespace, LabelSelector: labelSelector}); err != nil {
+			return nil, err
+		}
+		for i := range stsList.Items {
+			statefulSets[stsList.Items[i].Name] = &stsList.Items[i]
+		}
+	}
+
+	if len(statefulSets) == 0 {
+		return nil, nil
+	}
+
+	group := make([]*apps.StatefulSet, 0, len(statefulSets))
+	for _, sts := range statefulSets {
+		group = append(group, sts)
+	}
+	return newStatefulSetWorkload(r.Client, group...), nil
+}
+
+// isStatefulSetGroup returns true if the ZAU selects multiple StatefulSets to be rolled out together.
+func isStatefulSetGroup(zau *opsv1.ZoneAwareUpdate) bool {
+	return zau.Spec.Workload == nil && (len(zau.Spec.StatefulSets) > 0 || zau.Spec.StatefulSetSelector != nil)
+}
+
+// targetsStatefulSets returns true if the ZAU rolls out one or more StatefulSets.
+func targetsStatefulSets(zau *opsv1.ZoneAwareUpdate) bool {
+	kind, _ := workloadRef(zau)
+	return isStatefulSetGroup(zau) || kind == utils.ControllerKindSS.Kind
+}
+
+// workloadRef returns the kind and name of the workload referenced by the ZAU.
+func workloadRef(zau *opsv1.ZoneAwareUpdate) (string, string) {
+	if zau.Spec.Workload != nil {
+		return zau.Spec.Workload.Kind, zau.Spec.Workload.Name
+	}
+	return utils.ControllerKindSS.Kind, zau.Spec.StatefulSet
+}
+
+// zauTargetsWorkload returns true if the workload object of the given kind is rolled out by the ZAU.
+func zauTargetsWorkload(zau *opsv1.ZoneAwareUpdate, kind string, obj client.Object) bool {
+	if isStatefulSetGroup(zau) {
+		if kind != utils.ControllerKindSS.Kind {
+			return false
+		}
+		for _, name := range zau.Spec.StatefulSets {
+			if name == obj.GetName() {
+				return true
+			}
+		}
+		if zau.Spec.StatefulSetSelector != nil {
+			labelSelector, err := metav1.LabelSelectorAsSelector(zau.Spec.StatefulSetSelector)
+			if err != nil {
+				return false
+			}
+			return !labelSelector.Empty() && labelSelector.Matches(labels.Set(obj.GetLabels()))
+		}
+		return false
+	}
+
+	zauKind, zauName := workloadRef(zau)
+	return zauKind == kind && zauName == obj.GetName()
+}
+
+func listPods(ctx context.Context, c client.Client, namespace string, selector *metav1.LabelSelector) ([]*v1.Pod, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return []*v1.Pod{}, err
+	}
+
+	listOptions := &client.ListOptions{Namespace: namespace, LabelSelector: labelSelector}
+	podList := &v1.PodList{}
+	if err := c.List(ctx, podList, listOptions); err != nil {
+		return []*v1.Pod{}, err
+	}
+
+	pods := make([]*v1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	return pods, nil
+}
+
+// statefulSetWorkload rolls out one or more StatefulSets with the OnDelete update strategy by deleting
+// pods in the old revision, which are then recreated by the StatefulSet controller. When multiple
+// StatefulSets are rolled out together, they are handled as a single unit: revisions are joined
+// in the StatefulSet names order and pods of all StatefulSets are updated zone by zone.
+type statefulSetWorkload struct {
+	client.Client
+	statefulSets []*apps.StatefulSet
+}
+
+func newStatefulSetWorkload(c client.Client, statefulSets ...*apps.StatefulSet) *statefulSetWorkload {
+	sort.Slice(statefulSets, func(i, j int) bool {
+		return statefulSets[i].Name < statefulSets[j].Name
+	})
+	return &statefulSetWorkload{Client: c, statefulSets: statefulSets}
+}
+
+func (w *statefulSetWorkload) Kind() string {
+	return utils.ControllerKindSS.Kind
+}
+
+func (w *statefulSetWorkload) Name() string {
+	names := make([]string, 0, len(w.statefulSets))
+	for _, sts := range w.statefulSets {
+		names = append(names, sts.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func (w *statefulSetWorkload) UpdateStrategyReady() bool {
+	for _, sts := range w.statefulSets {
+		if sts.Spec.UpdateStrategy.Type != apps.OnDeleteStatefulSetStrategyType {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *statefulSetWorkload) Pods(ctx context.Context) ([]*v1.Pod, error) {
+	var pods []*v1.Pod
+	seen := map[string]bool{}
+	for _, sts := range w.statefulSets {
+		stsPods, err := listPods(ctx, w.Client, sts.Namespace, sts.Spec.Selector)
+		if err != nil {
+			return []*v1.Pod{}, err
+		}
+		for _, pod := range stsPods {
+			if !seen[pod.Name] {
+				seen[pod.Name] = true
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
+
+func (w *statefulSetWorkload) PodRevision(pod *v1.Pod) (string, bool) {
+	rev, ok := pod.Labels[apps.ControllerRevisionHashLabelKey]
+	return rev, ok
+}
+
+func (w *statefulSetWorkload) IsUpdated(pod *v1.Pod) bool {
+	rev, _ := w.PodRevision(pod)
+	if len(w.statefulSets) == 1 {
+		return rev == w.statefulSets[0].Status.UpdateRevision
+	}
+	controllerRef := metav1.GetControllerOf(pod)
+	for _, sts := range w.statefulSets {
+		if controllerRef != nil && controllerRef.UID == sts.UID {
+			return rev == sts.Status.UpdateRevision
+		}
+	}
+	return false
+}
+
+func (w *statefulSetWorkload) CurrentRevision() string {
+	revisions := make([]string, 0, len(w.statefulSets))
+	for _, sts := range w.statefulSets {
+		revisions = append(revisions, sts.Status.CurrentRevision)
+	}
+	return strings.Join(revisions, ",")
+}
+
+func (w *statefulSetWorkload) UpdateRevision() string {
+	revisions := make([]string, 0, len(w.statefulSets))
+	for _, sts := range w.statefulSets {
+		revisions = append(revisions, sts.Status.UpdateRevision)
+	}
+	return strings.Join(revisions, ",")
+}
+
+func (w *statefulSetWorkload) Replicas() int32 {
+	replicas := int32(0)
+	for _, sts := range w.statefulSets {
+		replicas += sts.Status.Replicas
+	}
+	return replicas
+}
+
+func (w *statefulSetWorkload) AllReplicasReady() bool {
+	for _, sts := range w.statefulSets {
+		if sts.Status.Replicas != sts.Status.ReadyReplicas {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *statefulSetWorkload) MinReadySeconds() int32 {
+	minReadySeconds := int32(0)
+	for _, sts := range w.statefulSets {
+		if sts.Spec.MinReadySeconds > minReadySeconds {
+			minReadySeconds = sts.Spec.MinReadySeconds
+		}
+	}
+	return minReadySeconds
+}
+
+func (w *statefulSetWorkload) ReplacePods(ctx context.Context, pods []*v1.Pod) error {
+	for _, pod := range pods {
+		if err := w.Delete(ctx, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *statefulSetWorkload) CompleteRollout(ctx context.Context) error {
+	for _, sts := range w.statefulSets {
+		if sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+			sts.Status.CurrentRevision = sts.Status.UpdateRevision
+			if err := w.Status().Update(ctx, sts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *statefulSetWorkload) Rollback(ctx context.Context) error {
+	for _, sts := range w.statefulSets {
+		if sts.Status.CurrentRevision == "" || sts.Status.CurrentRevision == sts.Status.UpdateRevision {
+			continue
+		}
+		// StatefulSet revisions are named after the revision hash, and store the pod template as a patch
+		revision := &apps.ControllerRevision{}
+		if err := w.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: sts.Status.CurrentRevision}, revision); err != nil {
+			return err
+		}
+		if err := w.Patch(ctx, sts, client.RawPatch(types.StrategicMergePatchType, revision.Data.Raw)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deploymentWorkload rolls out a paused Deployment with the Recreate strategy by moving replicas from the old ReplicaSets to the
+// ReplicaSet of the current pod template, which is created if needed. The pods to be replaced get the
+// lowest deletion cost, so they are the ones removed when the old ReplicaSets are scaled down.
+type deploymentWorkload struct {
+	client.Client
+	deployment *apps.Deployment
+	newRS      *apps.ReplicaSet
+	oldRSs     []*apps.ReplicaSet
+	updateRev  string
+	currentRev string
+}
+
+func newDeploymentWorkload(ctx context.Context, c client.Client, deployment *apps.Deployment) (*deploymentWorkload, error) {
+	w := &deploymentWorkload{Client: c, deployment: deployment}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	rsList := &apps.ReplicaSetList{}
+	if err := c.List(ctx, rsList, &client.ListOptions{Namespace: deployment.Namespace, LabelSelector: labelSelector}); err != nil {
+		return nil, err
+	}
+
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		if w.newRS == nil && utils.EqualIgnoreHash(&rs.Spec.Template, &deployment.Spec.Template) {
+			w.newRS = rs
+		} else {
+			w.oldRSs = append(w.oldRSs, rs)
+		}
+	}
+
+	if w.newRS != nil {
+		w.updateRev = w.newRS.Labels[apps.DefaultDeploymentUniqueLabelKey]
+	} else {
+		w.updateRev = utils.ComputeHash(&deployment.Spec.Template, deployment.Status.CollisionCount)
+	}
+
+	// The current revision is the one with the most replicas among the old ReplicaSets.
+	w.currentRev = w.updateRev
+	maxReplicas := int32(0)
+	for _, rs := range w.oldRSs {
+		if rs.Spec.Replicas != nil && *rs.Spec.Replicas > maxReplicas {
+			maxReplicas = *rs.Spec.Replicas
+			w.currentRev = rs.Labels[apps.DefaultDeploymentUniqueLabelKey]
+		}
+	}
+	return w, nil
+}
+
+func (w *deploymentWorkload) Kind() string {
+	return utils.ControllerKindDeployment.Kind
+}
+
+func (w *deploymentWorkload) Name() string {
+	return w.deployment.Name
+}
+
+// UpdateStrategyReady returns true if the deployment is paused and uses the Recreate strategy. The deployment
+// controller still scales the ReplicaSets of paused deployments, and with the RollingUpdate strategy it spreads
+// the max surge across the old and new ReplicaSets, creating pods outside of the zones being updated.
+func (w *deploymentWorkload) UpdateStrategyReady() bool {
+	return w.deployment.Spec.Paused && w.deployment.Spec.Strategy.Type == apps.RecreateDeploymentStrategyType
+}
+
+func (w *deploymentWorkload) Pods(ctx context.Context) ([]*v1.Pod, error) {
+	return listPods(ctx, w.Client, w.deployment.Namespace, w.deployment.Spec.Selector)
+}
+
+func (w *deploymentWorkload) PodRevision(pod *v1.Pod) (string, bool) {
+	rev, ok := pod.Labels[apps.DefaultDeploymentUniqueLabelKey]
+	return rev, ok
+}
+
+func (w *deploymentWorkload) IsUpdated(pod *v1.Pod) bool {
+	rev, _ := w.PodRevision(pod)
+	return rev == w.updateRev
+}
+
+func (w *deploymentWorkload) CurrentRevision() string {
+	return w.currentRev
+}
+
+func (w *deploymentWorkload) UpdateRevision() string {
+	return w.updateRev
+}
+
+func (w *deploymentWorkload) Replicas() int32 {
+	if w.deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *w.deployment.Spec.Replicas
+}
+
+func (w *deploymentWorkload) AllReplicasReady() bool {
+	return w.deployment.Status.Replicas == w.deployment.Status.ReadyReplicas
+}
+
+func (w *deploymentWorkload) MinReadySeconds() int32 {
+	return w.deployment.Spec.MinReadySeconds
+}
+
+func (w *deploymentWorkload) ReplacePods(ctx context.Context, pods []*v1.Pod) error {
+	if w.newRS == nil {
+		newRS := utils.NewReplicaSet(w.deployment, utils.MaxRevision(w.oldRSs)+1)
+		if err := w.Create(ctx, newRS); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		if err := w.Get(ctx, client.ObjectKeyFromObject(newRS), newRS); err != nil {
+			return err
+		}
+		w.newRS = newRS
+	}
+
+	scaleDown := map[string]int32{}
+	for _, pod := range pods {
+		controllerRef := metav1.GetControllerOf(pod)
+		if controllerRef == nil || controllerRef.Kind != utils.ControllerKindRS.Kind {
+			return fmt.Errorf("pod %q is not controlled by a ReplicaSet", pod.Name)
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[v1.PodDeletionCost] = strconv.Itoa(replacedPodDeletionCost)
+		if err := w.Patch(ctx, pod, patch); err != nil {
+			return err
+		}
+		scaleDown[controllerRef.Name]++
+	}
+
+	// The new ReplicaSet is scaled up first: the deployment controller scales the only active ReplicaSet
+	// back to the deployment replicas, and leaves them alone once both are active.
+	total := int32(0)
+	for _, rs := range w.oldRSs {
+		total += scaleDown[rs.Name]
+	}
+	if err := w.scaleReplicaSet(ctx, w.newRS, total); err != nil {
+		return err
+	}
+	for _, rs := range w.oldRSs {
+		count, ok := scaleDown[rs.Name]
+		if !ok {
+			continue
+		}
+		if err := w.scaleReplicaSet(ctx, rs, -count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *deploymentWorkload) scaleReplicaSet(ctx context.Context, rs *apps.ReplicaSet, delta int32) error {
+	patch := client.MergeFromWithOptions(rs.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	replicas := delta
+	if rs.Spec.Replicas != nil {
+		replicas += *rs.Spec.Replicas
+	}
+	if replicas < 0 {
+		replicas = 0
+	}
+	rs.Spec.Replicas = &replicas
+	return w.Patch(ctx, rs, patch)
+}
+
+func (w *deploymentWorkload) CompleteRollout(ctx context.Context) error {
+	return nil
+}
+
+func (w *deploymentWorkload) Rollback(ctx context.Context) error {
+	if w.currentRev == w.updateRev {
+		return nil
+	}
+	for _, rs := range w.oldRSs {
+		if rs.Labels[apps.DefaultDeploymentUniqueLabelKey] != w.currentRev {
+			continue
+		}
+		patch := client.MergeFrom(w.deployment.DeepCopy())
+		w.deployment.Spec.Template = *rs.Spec.Template.DeepCopy()
+		delete(w.deployment.Spec.Template.Labels, apps.DefaultDeploymentUniqueLabelKey)
+		return w.Patch(ctx, w.deployment, patch)
+	}
+	return fmt.Errorf("no replicaset found for revision %q of deployment %q", w.currentRev, w.deployment.Name)
+}
+
+// daemonSetWorkload rolls out a DaemonSet with the OnDelete update strategy by deleting pods in the old
+// revision, which are then recreated on the same nodes by the DaemonSet controller. The update revision
+// is the newest ControllerRevision owned by the DaemonSet.
+type daemonSetWorkload struct {
+	client.Client
+	ds         *apps.DaemonSet
+	pods       []*v1.Pod
+	revisions  map[string]*apps.ControllerRevision
+	updateRev  string
+	currentRev string
+}
+
+func newDaemonSetWorkload(ctx context.Context, c client.Client, ds *apps.DaemonSet) (*daemonSetWorkload, error) {
+	w := &daemonSetWorkload{Client: c, ds: ds, revisions: map[string]*apps.ControllerRevision{}}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	revisionList := &apps.ControllerRevisionList{}
+	if err := c.List(ctx, revisionList, &client.ListOptions{Namespace: ds.Namespace, LabelSelector: labelSelector}); err != nil {
+		return nil, err
+	}
+
+	var updateRevision *apps.ControllerRevision
+	for i := range revisionList.Items {
+		revision := &revisionList.Items[i]
+		if !metav1.IsControlledBy(revision, ds) {
+			continue
+		}
+		w.revisions[revision.Labels[apps.DefaultDaemonSetUniqueLabelKey]] = revision
+		if updateRevision == nil || revision.Revision > updateRevision.Revision {
+			updateRevision = revision
+		}
+	}
+	if updateRevision == nil {
+		return nil, fmt.Errorf("no controller revision found for daemonset %q", ds.Name)
+	}
+	w.updateRev = updateRevision.Labels[apps.DefaultDaemonSetUniqueLabelKey]
+
+	w.pods, err = listPods(ctx, c, ds.Namespace, ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// The current revision is the one with the most pods among the old revisions.
+	w.currentRev = w.updateRev
+	podsPerRevision := map[string]int{}
+	for _, pod := range w.pods {
+		rev, ok := w.PodRevision(pod)
+		if !ok || rev == w.updateRev {
+			continue
+		}
+		podsPerRevision[rev]++
+		if podsPerRevision[rev] > podsPerRevision[w.currentRev] {
+			w.currentRev = rev
+		}
+	}
+	return w, nil
+}
+
+func (w *daemonSetWorkload) Kind() string {
+	return utils.ControllerKindDS.Kind
+}
+
+func (w *daemonSetWorkload) Name() string {
+	return w.ds.Name
+}
+
+func (w *daemonSetWorkload) UpdateStrategyReady() bool {
+	return w.ds.Spec.UpdateStrategy.Type == apps.OnDeleteDaemonSetStrategyType
+}
+
+func (w *daemonSetWorkload) Pods(ctx context.Context) ([]*v1.Pod, error) {
+	return w.pods, nil
+}
+
+func (w *daemonSetWorkload) PodRevision(pod *v1.Pod) (string, bool) {
+	rev, ok := pod.Labels[apps.DefaultDaemonSetUniqueLabelKey]
+	return rev, ok
+}
+
+func (w *daemonSetWorkload) IsUpdated(pod *v1.Pod) bool {
+	rev, _ := w.PodRevision(pod)
+	return rev == w.updateRev
+}
+
+func (w *daemonSetWorkload) CurrentRevision() string {
+	return w.currentRev
+}
+
+func (w *daemonSetWorkload) UpdateRevision() string {
+	return w.updateRev
+}
+
+func (w *daemonSetWorkload) Replicas() int32 {
+	return w.ds.Status.DesiredNumberScheduled
+}
+
+func (w *daemonSetWorkload) AllReplicasReady() bool {
+	return w.ds.Status.DesiredNumberScheduled == w.ds.Status.NumberReady
+}
+
+func (w *daemonSetWorkload) MinReadySeconds() int32 {
+	return w.ds.Spec.MinReadySeconds
+}
+
+func (w *daemonSetWorkload) ReplacePods(ctx context.Context, pods []*v1.Pod) error {
+	for _, pod := range pods {
+		if err := w.Delete(ctx, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *daemonSetWorkload) CompleteRollout(ctx context.Context) error {
+	return nil
+}
+
+func (w *daemonSetWorkload) Rollback(ctx context.Context) error {
+	if w.currentRev == w.updateRev {
+		return nil
+	}
+	// DaemonSet revisions store the pod template as a patch
+	revision, ok := w.revisions[w.currentRev]
+	if !ok {
+		return fmt.Errorf("no controller revision found for revision %q of daemonset %q", w.currentRev, w.ds.Name)
+	}
+	return w.Patch(ctx, w.ds, client.RawPatch(types.StrategicMergePatchType, revision.Data.Raw))
+}
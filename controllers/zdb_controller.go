@@ -24,14 +24,18 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -62,6 +66,7 @@ type ZoneDisruptionBudgetReconciler struct {
 	Scheme        *runtime.Scheme
 	Logger        logr.Logger
 	PodZoneHelper *podzone.Helper
+	Recorder      record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=zonecontrol.k8s.aws,resources=zonedisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
@@ -70,6 +75,7 @@ type ZoneDisruptionBudgetReconciler struct {
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=pods/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -103,6 +109,7 @@ func (r *ZoneDisruptionBudgetReconciler) Reconcile(ctx context.Context, req ctrl
 func (r *ZoneDisruptionBudgetReconciler) sync(ctx context.Context, zdb *opsv1.ZoneDisruptionBudget) (*time.Time, error) {
 	pods, err := r.getPodsForZdb(ctx, zdb)
 	if err != nil {
+		r.updateSyncFailedStatus(ctx, zdb, err)
 		return nil, err
 	}
 	if len(pods) == 0 {
@@ -112,6 +119,7 @@ func (r *ZoneDisruptionBudgetReconciler) sync(ctx context.Context, zdb *opsv1.Zo
 	expectedCount, desiredHealthy, zonePodsMap, err := r.getExpectedPodCount(ctx, zdb, pods)
 	if err != nil {
 		r.Logger.Error(err, "Failed to calculate the number of expected pods")
+		r.updateSyncFailedStatus(ctx, zdb, err)
 		return nil, err
 	}
 
@@ -229,6 +237,9 @@ func (r *ZoneDisruptionBudgetReconciler) buildDisruptedPodMap(pods []*v1.Pod, zd
 		expectedDeletion := disruptionTime.Time.Add(DeletionTimeout)
 		if expectedDeletion.Before(currentTime) {
 			r.Logger.Info("Pod was expected to be deleted but it wasn't", "pod", pod.Name, "disruptionTime", disruptionTime.String())
+			r.Recorder.Eventf(pod, v1.EventTypeWarning, "EvictionNotObserved",
+				"Pod eviction was allowed by ZoneDisruptionBudget %s at %s, but the pod wasn't deleted",
+				zdb.Name, disruptionTime.UTC().Format(time.RFC3339))
 		} else {
 			if recheckTime == nil || expectedDeletion.Before(*recheckTime) {
 				recheckTime = &expectedDeletion
@@ -354,12 +365,15 @@ func (r *ZoneDisruptionBudgetReconciler) updateStatus(ctx context.Context, zdb *
 		}
 	}
 
+	conditions := zdbConditions(zdb, currentHealthy, desiredHealthy, expectedCount, disruptionsAllowed, disruptedZones)
+
 	if reflect.DeepEqual(zdb.Status.CurrentHealthy, currentHealthy) &&
 		reflect.DeepEqual(zdb.Status.CurrentUnhealthy, currentUnhealthy) &&
 		reflect.DeepEqual(zdb.Status.DesiredHealthy, desiredHealthy) &&
 		reflect.DeepEqual(zdb.Status.ExpectedPods, expectedCount) &&
 		reflect.DeepEqual(zdb.Status.DisruptedPods, disruptedPods) &&
 		reflect.DeepEqual(zdb.Status.DisruptionsAllowed, disruptionsAllowed) &&
+		reflect.DeepEqual(zdb.Status.Conditions, conditions) &&
 		zdb.Status.ObservedGeneration == zdb.Generation {
 		return nil
 	}
@@ -369,7 +383,18 @@ func (r *ZoneDisruptionBudgetReconciler) updateStatus(ctx context.Context, zdb *
 	zdb.Status.DesiredHealthy = desiredHealthy
 	zdb.Status.ExpectedPods = expectedCount
 	zdb.Status.DisruptedPods = disruptedPods
+	previousBlocked := meta.FindStatusCondition(zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionBlocked)
+	if blocked := meta.FindStatusCondition(conditions, opsv1.ZoneDisruptionBudgetConditionBlocked); previousBlocked == nil ||
+		previousBlocked.Status != blocked.Status {
+		if blocked.Status == metav1.ConditionTrue {
+			r.Recorder.Eventf(zdb, v1.EventTypeWarning, "DisruptionsBlocked", "No disruptions allowed in any zone: %s", blocked.Message)
+		} else if previousBlocked != nil {
+			r.Recorder.Eventf(zdb, v1.EventTypeNormal, "DisruptionsAllowed", "%s", blocked.Message)
+		}
+	}
+
 	zdb.Status.DisruptionsAllowed = disruptionsAllowed
+	zdb.Status.Conditions = conditions
 	zdb.Status.ObservedGeneration = zdb.Generation
 
 	err := r.Client.Status().Update(ctx, zdb)
@@ -382,6 +407,62 @@ func (r *ZoneDisruptionBudgetReconciler) updateStatus(ctx context.Context, zdb *
 	return nil
 }
 
+// zdbConditions returns the ZDB conditions for the computed status.
+func zdbConditions(zdb *opsv1.ZoneDisruptionBudget, currentHealthy, desiredHealthy, expectedCount,
+	disruptionsAllowed map[string]int32, disruptedZones []string) []metav1.Condition {
+
+	conditions := append([]metav1.Condition{}, zdb.Status.Conditions...)
+	utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionReady, true, "SyncSucceeded", "", zdb.Generation)
+
+	var insufficientZones, allowedZones []string
+	for zone := range desiredHealthy {
+		if currentHealthy[zone] < desiredHealthy[zone] {
+			insufficientZones = append(insufficientZones, zone)
+		}
+		if disruptionsAllowed[zone] > 0 {
+			allowedZones = append(allowedZones, zone)
+		}
+	}
+	sort.Strings(insufficientZones)
+	sort.Strings(allowedZones)
+	sort.Strings(disruptedZones)
+
+	if len(expectedCount) == 0 {
+		utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionInsufficientPods, true, "NoPods",
+			"No pods match the selector", zdb.Generation)
+	} else if len(insufficientZones) > 0 {
+		utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionInsufficientPods, true, "InsufficientPods",
+			fmt.Sprintf("Fewer healthy pods than desired in zones %s", strings.Join(insufficientZones, ", ")), zdb.Generation)
+	} else {
+		utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionInsufficientPods, false, "SufficientPods", "", zdb.Generation)
+	}
+
+	if len(allowedZones) > 0 {
+		utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionBlocked, false, "DisruptionsAllowed",
+			fmt.Sprintf("Disruptions allowed in zones %s", strings.Join(allowedZones, ", ")), zdb.Generation)
+	} else if len(disruptedZones) > 1 {
+		utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionBlocked, true, "UnhealthyPodsInMultipleZones",
+			fmt.Sprintf("Unhealthy pods in zones %s", strings.Join(disruptedZones, ", ")), zdb.Generation)
+	} else {
+		utils.SetCondition(&conditions, opsv1.ZoneDisruptionBudgetConditionBlocked, true, "NoDisruptionsAllowed",
+			"No healthy pods above the desired number in any zone", zdb.Generation)
+	}
+	return conditions
+}
+
+// updateSyncFailedStatus sets the Ready condition to false when the ZDB status can't be computed.
+func (r *ZoneDisruptionBudgetReconciler) updateSyncFailedStatus(ctx context.Context, zdb *opsv1.ZoneDisruptionBudget, syncErr error) {
+	condition := meta.FindStatusCondition(zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionReady)
+	if condition != nil && condition.Status == metav1.ConditionFalse && condition.Message == syncErr.Error() {
+		return
+	}
+	utils.SetCondition(&zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionReady, false, "SyncFailed", syncErr.Error(), zdb.Generation)
+	r.Recorder.Eventf(zdb, v1.EventTypeWarning, "SyncFailed", "Unable to compute the ZDB status: %v", syncErr)
+	if err := r.Client.Status().Update(ctx, zdb); err != nil {
+		r.Logger.Error(err, "Unable to update zdb status")
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ZoneDisruptionBudgetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
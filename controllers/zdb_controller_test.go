@@ -5,9 +5,11 @@ import (
 	"strconv"
 	"time"
 
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -43,6 +45,9 @@ var _ = Describe("ZDB Controller", func() {
 				Expect(len(zdb.Status.DisruptedPods)).Should(Equal(0))
 				Expect(zdb.Status.ObservedGeneration).Should(Equal(int64(1)))
 			}
+			Expect(meta.IsStatusConditionTrue(zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionReady)).Should(BeTrue())
+			Expect(meta.IsStatusConditionFalse(zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionInsufficientPods)).Should(BeTrue())
+			Expect(meta.IsStatusConditionFalse(zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionBlocked)).Should(BeTrue())
 		})
 	})
 
@@ -124,6 +129,10 @@ var _ = Describe("ZDB Controller", func() {
 				Expect(len(zdb.Status.DisruptedPods)).Should(Equal(0))
 				Expect(zdb.Status.ObservedGeneration).Should(Equal(int64(1)))
 			}
+			blocked := meta.FindStatusCondition(zdb.Status.Conditions, opsv1.ZoneDisruptionBudgetConditionBlocked)
+			Expect(blocked).ShouldNot(BeNil())
+			Expect(blocked.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(blocked.Reason).Should(Equal("UnhealthyPodsInMultipleZones"))
 		})
 	})
 
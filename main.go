@@ -22,8 +22,11 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -52,6 +55,9 @@ const (
 	CONTROLLERS_ENV_DEFAULT_VALUE = "zdb,zau"
 	CONTROLLERS_ENV_ZAU_VALUE     = "zau"
 	CONTROLLERS_ENV_ZDB_VALUE     = "zdb"
+
+	ALARM_PROVIDER_CLOUDWATCH = "cloudwatch"
+	ALARM_PROVIDER_CONFIGMAP  = "configmap"
 )
 
 var (
@@ -70,11 +76,27 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var prometheusAddr string
+	var alarmProvider string
+	var alarmConfigMap string
+	var alarmCacheTTL time.Duration
+	var alarmAPIQPS float64
+	var alarmAPIBurst int
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&prometheusAddr, "prometheus-address", "",
+		"The address of the Prometheus server evaluating the PauseRolloutQuery of ZoneAwareUpdates (e.g. http://prometheus:9090).")
+	flag.StringVar(&alarmProvider, "alarm-provider", ALARM_PROVIDER_CLOUDWATCH,
+		"The provider of the pause alarm states of ZoneAwareUpdates: cloudwatch or configmap.")
+	flag.StringVar(&alarmConfigMap, "alarm-configmap", "zone-aware-controllers-system/rollout-alarms",
+		"The namespace/name of the ConfigMap with the pause alarm states, when the alarm provider is configmap.")
+	flag.DurationVar(&alarmCacheTTL, "alarm-cache-ttl", 30*time.Second,
+		"How long alarm states are cached, shared by the ZoneAwareUpdates referencing the same alarms.")
+	flag.Float64Var(&alarmAPIQPS, "alarm-api-qps", 5, "The maximum number of calls per second to the alarm API.")
+	flag.IntVar(&alarmAPIBurst, "alarm-api-burst", 10, "The maximum burst of calls to the alarm API.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -115,6 +137,7 @@ func main() {
 			Scheme:        mgr.GetScheme(),
 			Logger:        ctrl.Log.WithName("zdb-controller"),
 			PodZoneHelper: &podZoneHelper,
+			Recorder:      mgr.GetEventRecorderFor("zdb-controller"),
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ZoneDisruptionBudget")
 			os.Exit(1)
@@ -122,21 +145,30 @@ func main() {
 	}
 
 	if startZau {
-		region := os.Getenv("AWS_REGION") // AWS_REGION env is set by EKS
-		cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
+		alarmStateProvider, err := newAlarmStateProvider(mgr, alarmProvider, alarmConfigMap)
 		if err != nil {
-			setupLog.Error(err, "unable to load AWS config")
+			setupLog.Error(err, "unable to create alarm state provider", "provider", alarmProvider)
 			os.Exit(1)
 		}
-		cwClient := cloudwatch.NewFromConfig(cfg)
-		cwAlarmStateProvider := &utils.CloudWatchAlarmStateProvider{Client: cwClient}
+		cachingAlarmStateProvider := utils.NewCachingAlarmStateProvider(alarmStateProvider, alarmCacheTTL, alarmAPIQPS, alarmAPIBurst)
+
+		var queryStateProvider utils.QueryStateProvider
+		if prometheusAddr != "" {
+			queryStateProvider = &utils.PrometheusQueryStateProvider{
+				Address: prometheusAddr,
+				Client:  &http.Client{Timeout: 10 * time.Second},
+			}
+		}
 
 		if err = (&controllers.ZoneAwareUpdateReconciler{
 			Client:             mgr.GetClient(),
 			Scheme:             mgr.GetScheme(),
 			Logger:             ctrl.Log.WithName("zau-controller"),
 			PodZoneHelper:      &podZoneHelper,
-			AlarmStateProvider: cwAlarmStateProvider,
+			AlarmStateProvider: cachingAlarmStateProvider,
+			QueryStateProvider: queryStateProvider,
+			HealthGateChecker:  &utils.HTTPHealthGateChecker{Client: &http.Client{}},
+			Recorder:           mgr.GetEventRecorderFor("zau-controller"),
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ZoneAwareUpdate")
 			os.Exit(1)
@@ -145,12 +177,24 @@ func main() {
 	//+kubebuilder:scaffold:builder
 
 	// Setup core types webhooks
+	hookServer := mgr.GetWebhookServer()
 	if startZdb {
-		hookServer := mgr.GetWebhookServer()
-		setupLog.Info("registering webhooks to the webhook server")
+		setupLog.Info("registering the eviction webhook to the webhook server")
 		hookServer.Register("/pod-eviction-v1", &webhook.Admission{Handler: &web.PodEvictionHandler{
+			Client:   mgr.GetClient(),
+			Logger:   ctrl.Log.WithName("eviction-webhook"),
+			Recorder: mgr.GetEventRecorderFor("eviction-webhook"),
+		}})
+	}
+	if startZau {
+		setupLog.Info("registering the zau webhooks to the webhook server")
+		hookServer.Register("/validate-zau-v1", &webhook.Admission{Handler: &web.ZoneAwareUpdateValidator{
+			Client: mgr.GetClient(),
+			Logger: ctrl.Log.WithName("zau-webhook"),
+		}})
+		hookServer.Register("/mutate-statefulset-v1", &webhook.Admission{Handler: &web.StatefulSetUpdateStrategyHandler{
 			Client: mgr.GetClient(),
-			Logger: ctrl.Log.WithName("eviction-webhook"),
+			Logger: ctrl.Log.WithName("statefulset-webhook"),
 		}})
 	}
 
@@ -180,3 +224,23 @@ func controllersToStart() (zdb bool, zau bool) {
 	zau = strings.Contains(controllersEnv, CONTROLLERS_ENV_ZAU_VALUE)
 	return zdb, zau
 }
+
+func newAlarmStateProvider(mgr ctrl.Manager, provider string, configMap string) (utils.AlarmStateProvider, error) {
+	switch provider {
+	case ALARM_PROVIDER_CLOUDWATCH:
+		region := os.Getenv("AWS_REGION") // AWS_REGION env is set by EKS
+		cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		}
+		return &utils.CloudWatchAlarmStateProvider{Client: cloudwatch.NewFromConfig(cfg)}, nil
+	case ALARM_PROVIDER_CONFIGMAP:
+		namespace, name, found := strings.Cut(configMap, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid alarm ConfigMap %q, expected namespace/name", configMap)
+		}
+		return &utils.ConfigMapAlarmStateProvider{Client: mgr.GetAPIReader(), Namespace: namespace, Name: name}, nil
+	default:
+		return nil, fmt.Errorf("unknown alarm provider %q", provider)
+	}
+}
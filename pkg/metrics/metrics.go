@@ -1,7 +1,10 @@
 package metrics
 
 import (
+	"strings"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -14,6 +17,7 @@ var (
 	zdbEvictionMetricLabels = []string{"status", "reason"}
 	zauMetricLabels         = []string{"namespace", "zau"}
 	zauPerZoneMetricLabels  = []string{"namespace", "zau", "zone"}
+	zauPausedMetricLabels   = []string{"namespace", "zau", "cause"}
 
 	currentHealth = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -104,16 +108,55 @@ var (
 	zauPausedRollout = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "zau_paused_rollout",
-			Help: "Returns if rollout is paused or not",
+			Help: "Returns if rollout is paused or not, by cause of the pause (alarm, manual, healthgate or alarmfailure)",
+		},
+		zauPausedMetricLabels,
+	)
+	zauAwaitingApproval = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zau_awaiting_approval",
+			Help: "Returns if rollout is waiting for a manual approval or not",
+		},
+		zauMetricLabels,
+	)
+	zauFailedRollout = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zau_failed_rollout",
+			Help: "Returns if rollout failed or not, after exceeding its progress deadline or a failed health gate",
 		},
 		zauMetricLabels,
 	)
+	alarmAPICalls = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "zau_alarm_api_calls_total",
+			Help: "Number of calls to the alarm API to get alarm states",
+		},
+	)
+	alarmAPIThrottles = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "zau_alarm_api_throttles_total",
+			Help: "Number of calls to the alarm API that were throttled",
+		},
+	)
+	alarmAPIErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "zau_alarm_api_errors_total",
+			Help: "Number of calls to the alarm API that failed, excluding throttles",
+		},
+	)
+	alarmStateCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "zau_alarm_state_cache_hits_total",
+			Help: "Number of alarm states served from the cache",
+		},
+	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(currentHealth, currentUnhealth, zonesUnhealthy, desiredHealthy, expectedPods,
 		disruptionsAllowed, dryRunEnabled, evictionStatus, zauUpdateStep, zauDeletedReplicas,
-		zauOldReplicas, zauDryRunEnabled, zauPausedRollout)
+		zauOldReplicas, zauDryRunEnabled, zauPausedRollout, zauAwaitingApproval, zauFailedRollout,
+		alarmAPICalls, alarmAPIThrottles, alarmAPIErrors, alarmStateCacheHits)
 }
 
 func PublishZdbStatusMetrics(zdb *opsv1.ZoneDisruptionBudget) {
@@ -168,9 +211,37 @@ func PublishZauStatusMetrics(zau *opsv1.ZoneAwareUpdate) {
 		dryRun = 1
 	}
 	zauDryRunEnabled.WithLabelValues(zau.Namespace, zau.Name).Set(float64(dryRun))
-	pausedRollout := 0
-	if zau.Status.PausedRollout {
-		pausedRollout = 1
+	for _, reason := range []opsv1.PauseReason{opsv1.PauseReasonAlarm, opsv1.PauseReasonManual, opsv1.PauseReasonHealthGate,
+		opsv1.PauseReasonAlarmFailure} {
+		pausedRollout := 0
+		if zau.Status.PausedRollout && zau.Status.PauseReason == reason {
+			pausedRollout = 1
+		}
+		zauPausedRollout.WithLabelValues(zau.Namespace, zau.Name, strings.ToLower(string(reason))).Set(float64(pausedRollout))
+	}
+	awaitingApproval := 0
+	if zau.Status.Phase == opsv1.ZoneAwareUpdateAwaitingApproval {
+		awaitingApproval = 1
 	}
-	zauPausedRollout.WithLabelValues(zau.Namespace, zau.Name).Set(float64(pausedRollout))
+	zauAwaitingApproval.WithLabelValues(zau.Namespace, zau.Name).Set(float64(awaitingApproval))
+	failedRollout := 0
+	if meta.IsStatusConditionTrue(zau.Status.Conditions, opsv1.ZoneAwareUpdateConditionFailed) {
+		failedRollout = 1
+	}
+	zauFailedRollout.WithLabelValues(zau.Namespace, zau.Name).Set(float64(failedRollout))
+}
+
+// PublishAlarmAPICallMetrics counts a call to the alarm API, and whether it was throttled or failed.
+func PublishAlarmAPICallMetrics(err error, throttled bool) {
+	alarmAPICalls.Inc()
+	if throttled {
+		alarmAPIThrottles.Inc()
+	} else if err != nil {
+		alarmAPIErrors.Inc()
+	}
+}
+
+// PublishAlarmStateCacheHitMetrics counts an alarm state served from the cache.
+func PublishAlarmStateCacheHitMetrics() {
+	alarmStateCacheHits.Inc()
 }
@@ -10,6 +10,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -148,6 +150,50 @@ func (t *Utils) CreateStatefulSetPod(name string, zone string, phase v1.PodPhase
 	return t.UpdatePodStatus(pod, phase, v1.PodReady)
 }
 
+func (t *Utils) CreateReplicaSetPod(name string, zone string, phase v1.PodPhase, label string, rs *apps.ReplicaSet) *v1.Pod {
+	labels := t.testLabels(label)
+	labels[apps.DefaultDeploymentUniqueLabelKey] = rs.Labels[apps.DefaultDeploymentUniqueLabelKey]
+	node := t.GetOrCreateNode(name, zone)
+	pod := &v1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       metav1.NamespaceDefault,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rs, utils.ControllerKindRS)},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "any", Image: "any"}},
+			NodeName:   node.Name,
+		},
+	}
+	Expect(t.Client.Create(t.Ctx, pod)).Should(Succeed())
+
+	return t.UpdatePodStatus(pod, phase, v1.PodReady)
+}
+
+func (t *Utils) CreateDaemonSetPod(name string, zone string, phase v1.PodPhase, label string, revision string, ds *apps.DaemonSet) *v1.Pod {
+	labels := t.testLabels(label)
+	labels[apps.DefaultDaemonSetUniqueLabelKey] = revision
+	node := t.GetOrCreateNode(name, zone)
+	pod := &v1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       metav1.NamespaceDefault,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ds, utils.ControllerKindDS)},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "any", Image: "any"}},
+			NodeName:   node.Name,
+		},
+	}
+	Expect(t.Client.Create(t.Ctx, pod)).Should(Succeed())
+
+	return t.UpdatePodStatus(pod, phase, v1.PodReady)
+}
+
 func (t *Utils) UpdatePodStatus(pod *v1.Pod, phase v1.PodPhase, condition v1.PodConditionType) *v1.Pod {
 	pod.Status = v1.PodStatus{
 		Phase: phase,
@@ -264,6 +310,141 @@ func (t *Utils) GetStatefulSet(ssName string) *apps.StatefulSet {
 	return ssNew
 }
 
+func (t *Utils) CreateDeployment(replicas int32, label string, image string) *apps.Deployment {
+	labels := t.testLabels(label)
+	deployment := &apps.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      label + "-deployment",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: apps.DeploymentSpec{
+			Replicas: &replicas,
+			Paused:   true,
+			Strategy: apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType},
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "any", Image: image}},
+				},
+			},
+		},
+	}
+	Expect(t.Client.Create(t.Ctx, deployment)).Should(Succeed())
+	return t.GetDeployment(deployment.Name)
+}
+
+func (t *Utils) GetDeployment(name string) *apps.Deployment {
+	deployment := &apps.Deployment{}
+	key := types.NamespacedName{
+		Name:      name,
+		Namespace: metav1.NamespaceDefault,
+	}
+	Eventually(func() bool {
+		err := t.Client.Get(t.Ctx, key, deployment)
+		return err == nil
+	}, timeout, interval).Should(BeTrue())
+	return deployment
+}
+
+// CreateReplicaSet creates a ReplicaSet owned by the deployment, using the deployment's
+// current pod template with the given image.
+func (t *Utils) CreateReplicaSet(deployment *apps.Deployment, replicas int32, image string) *apps.ReplicaSet {
+	template := deployment.Spec.Template.DeepCopy()
+	template.Spec.Containers[0].Image = image
+	hash := utils.ComputeHash(template, nil)
+	template.Labels[apps.DefaultDeploymentUniqueLabelKey] = hash
+	selector := deployment.Spec.Selector.DeepCopy()
+	selector.MatchLabels[apps.DefaultDeploymentUniqueLabelKey] = hash
+
+	rs := &apps.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            deployment.Name + "-" + hash,
+			Namespace:       metav1.NamespaceDefault,
+			Labels:          template.Labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(deployment, utils.ControllerKindDeployment)},
+		},
+		Spec: apps.ReplicaSetSpec{
+			Replicas: &replicas,
+			Selector: selector,
+			Template: *template,
+		},
+	}
+	Expect(t.Client.Create(t.Ctx, rs)).Should(Succeed())
+	return t.GetReplicaSet(rs.Name)
+}
+
+func (t *Utils) GetReplicaSet(name string) *apps.ReplicaSet {
+	rs := &apps.ReplicaSet{}
+	key := types.NamespacedName{
+		Name:      name,
+		Namespace: metav1.NamespaceDefault,
+	}
+	Eventually(func() bool {
+		err := t.Client.Get(t.Ctx, key, rs)
+		return err == nil
+	}, timeout, interval).Should(BeTrue())
+	return rs
+}
+
+func (t *Utils) CreateDaemonSet(label string) *apps.DaemonSet {
+	labels := t.testLabels(label)
+	ds := &apps.DaemonSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      label + "-ds",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: apps.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			UpdateStrategy: apps.DaemonSetUpdateStrategy{
+				Type: apps.OnDeleteDaemonSetStrategyType,
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "any", Image: "any"}},
+				},
+			},
+		},
+	}
+	Expect(t.Client.Create(t.Ctx, ds)).Should(Succeed())
+
+	key := types.NamespacedName{
+		Name:      ds.Name,
+		Namespace: metav1.NamespaceDefault,
+	}
+	Eventually(func() bool {
+		err := t.Client.Get(t.Ctx, key, ds)
+		return err == nil
+	}, timeout, interval).Should(BeTrue())
+	return ds
+}
+
+// CreateControllerRevision creates a ControllerRevision owned by the object, with the given hash and revision number.
+func (t *Utils) CreateControllerRevision(owner client.Object, gvk schema.GroupVersionKind, label string, hash string, revision int64) *apps.ControllerRevision {
+	labels := t.testLabels(label)
+	labels[apps.ControllerRevisionHashLabelKey] = hash
+	cr := &apps.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            owner.GetName() + "-" + hash,
+			Namespace:       metav1.NamespaceDefault,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(owner, gvk)},
+		},
+		Data:     runtime.RawExtension{Raw: []byte("{}")},
+		Revision: revision,
+	}
+	Expect(t.Client.Create(t.Ctx, cr)).Should(Succeed())
+	return cr
+}
+
 func (t *Utils) CreateZau(statefulset string, maxUnavailable intstr.IntOrString, label string) *opsv1.ZoneAwareUpdate {
 	name := label + "-zau"
 	labels := t.testLabels(label)
@@ -23,19 +23,26 @@ type CloudWatchAlarmStateProvider struct {
 func (p *CloudWatchAlarmStateProvider) AlarmState(ctx context.Context, alarmName string) (types.StateValue, error) {
 	output, err := p.Client.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
 		AlarmNames: []string{alarmName},
-		AlarmTypes: []types.AlarmType{types.AlarmTypeCompositeAlarm},
+		AlarmTypes: []types.AlarmType{types.AlarmTypeCompositeAlarm, types.AlarmTypeMetricAlarm},
 	})
 	if err != nil {
 		return "", err
 	}
 
-	if len(output.CompositeAlarms) == 0 {
+	states := []types.StateValue{}
+	for _, alarm := range output.CompositeAlarms {
+		states = append(states, alarm.StateValue)
+	}
+	for _, alarm := range output.MetricAlarms {
+		states = append(states, alarm.StateValue)
+	}
+
+	if len(states) == 0 {
 		return "", fmt.Errorf("alarm not found: %s", alarmName)
 	}
-	if len(output.CompositeAlarms) > 1 {
-		return "", fmt.Errorf("multiple alarms found: %v", output.CompositeAlarms)
+	if len(states) > 1 {
+		return "", fmt.Errorf("multiple alarms found: %s", alarmName)
 	}
 
-	alarm := output.CompositeAlarms[0]
-	return alarm.StateValue, nil
+	return states[0], nil
 }
@@ -19,6 +19,9 @@ func TestCloudWatchAlarmStatusProvider(t *testing.T) {
 	okAlarm := types.CompositeAlarm{
 		StateValue: types.StateValueOk,
 	}
+	okMetricAlarm := types.MetricAlarm{
+		StateValue: types.StateValueOk,
+	}
 	tests := []struct {
 		name        string
 		output      *cloudwatch.DescribeAlarmsOutput
@@ -31,6 +34,13 @@ func TestCloudWatchAlarmStatusProvider(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "return metric alarm ok state",
+			output: &cloudwatch.DescribeAlarmsOutput{
+				MetricAlarms: []types.MetricAlarm{okMetricAlarm},
+			},
+			expectError: false,
+		},
 		{
 			name: "return no alarm",
 			output: &cloudwatch.DescribeAlarmsOutput{
@@ -45,12 +55,21 @@ func TestCloudWatchAlarmStatusProvider(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "return composite and metric alarms",
+			output: &cloudwatch.DescribeAlarmsOutput{
+				CompositeAlarms: []types.CompositeAlarm{okAlarm},
+				MetricAlarms:    []types.MetricAlarm{okMetricAlarm},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			provider := CloudWatchAlarmStateProvider{
 				Client: mockCloudWatchAPI(func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+					assert.ElementsMatch(t, []types.AlarmType{types.AlarmTypeCompositeAlarm, types.AlarmTypeMetricAlarm}, params.AlarmTypes)
 					return tt.output, nil
 				}),
 			}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"golang.org/x/time/rate"
+
+	"github.com/aws/zone-aware-controllers-for-k8s/pkg/metrics"
+)
+
+const (
+	minAlarmStateBackoff = time.Second
+	maxAlarmStateBackoff = 5 * time.Minute
+)
+
+// CachingAlarmStateProvider caches the alarm states returned by another AlarmStateProvider for the TTL, and rate
+// limits its calls. As the cache is keyed by alarm name, ZoneAwareUpdates referencing the same alarm share the same
+// calls. Errors are cached too, with an exponential backoff, so a failing or throttled API is not called on
+// every reconcile.
+type CachingAlarmStateProvider struct {
+	provider AlarmStateProvider
+	ttl      time.Duration
+	limiter  *rate.Limiter
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*alarmStateEntry
+}
+
+type alarmStateEntry struct {
+	state      types.StateValue
+	err        error
+	failures   int
+	expiration time.Time
+}
+
+// NewCachingAlarmStateProvider returns a CachingAlarmStateProvider calling the provider at most qps times per second,
+// with bursts of up to burst calls.
+func NewCachingAlarmStateProvider(provider AlarmStateProvider, ttl time.Duration, qps float64, burst int) *CachingAlarmStateProvider {
+	return &CachingAlarmStateProvider{
+		provider: provider,
+		ttl:      ttl,
+		limiter:  rate.NewLimiter(rate.Limit(qps), burst),
+		now:      time.Now,
+		entries:  map[string]*alarmStateEntry{},
+	}
+}
+
+func (p *CachingAlarmStateProvider) AlarmState(ctx context.Context, alarmName string) (types.StateValue, error) {
+	p.mu.Lock()
+	entry, found := p.entries[alarmName]
+	p.mu.Unlock()
+	if found && p.now().Before(entry.expiration) {
+		metrics.PublishAlarmStateCacheHitMetrics()
+		return entry.state, entry.err
+	}
+
+	if err := p.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	state, err := p.provider.AlarmState(ctx, alarmName)
+	metrics.PublishAlarmAPICallMetrics(err, isThrottleError(err))
+
+	newEntry := &alarmStateEntry{state: state, err: err, expiration: p.now().Add(p.ttl)}
+	if err != nil {
+		newEntry.failures = 1
+		if found {
+			newEntry.failures = entry.failures + 1
+		}
+		newEntry.expiration = p.now().Add(alarmStateBackoff(newEntry.failures))
+	}
+	p.mu.Lock()
+	p.entries[alarmName] = newEntry
+	p.mu.Unlock()
+	return state, err
+}
+
+// alarmStateBackoff returns the time to wait before calling the alarm API again after consecutive failures.
+func alarmStateBackoff(failures int) time.Duration {
+	backoff := minAlarmStateBackoff
+	for i := 1; i < failures && backoff < maxAlarmStateBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxAlarmStateBackoff {
+		return maxAlarmStateBackoff
+	}
+	return backoff
+}
+
+func isThrottleError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return retry.ThrottleErrorCode{Codes: retry.DefaultThrottleErrorCodes}.IsErrorThrottle(err) == aws.TrueTernary
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingAlarmStateProvider struct {
+	states map[string]types.StateValue
+	err    error
+	calls  map[string]int
+}
+
+func (p *countingAlarmStateProvider) AlarmState(ctx context.Context, alarmName string) (types.StateValue, error) {
+	p.calls[alarmName]++
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.states[alarmName], nil
+}
+
+type throttleError struct{}
+
+func (throttleError) Error() string     { return "rate exceeded" }
+func (throttleError) ErrorCode() string { return "Throttling" }
+
+func TestCachingAlarmStateProvider(t *testing.T) {
+	now := time.Now()
+	provider := &countingAlarmStateProvider{
+		states: map[string]types.StateValue{"alarm1": types.StateValueOk, "alarm2": types.StateValueAlarm},
+		calls:  map[string]int{},
+	}
+	cache := NewCachingAlarmStateProvider(provider, time.Minute, 100, 10)
+	cache.now = func() time.Time { return now }
+
+	// Alarm states are cached for the TTL
+	for i := 0; i < 3; i++ {
+		state, err := cache.AlarmState(context.TODO(), "alarm1")
+		assert.Nil(t, err)
+		assert.Equal(t, types.StateValueOk, state)
+		state, err = cache.AlarmState(context.TODO(), "alarm2")
+		assert.Nil(t, err)
+		assert.Equal(t, types.StateValueAlarm, state)
+	}
+	assert.Equal(t, map[string]int{"alarm1": 1, "alarm2": 1}, provider.calls)
+
+	now = now.Add(time.Minute)
+	provider.states["alarm1"] = types.StateValueAlarm
+	state, err := cache.AlarmState(context.TODO(), "alarm1")
+	assert.Nil(t, err)
+	assert.Equal(t, types.StateValueAlarm, state)
+	assert.Equal(t, 2, provider.calls["alarm1"])
+
+	// Errors are cached with an exponential backoff
+	provider.err = throttleError{}
+	now = now.Add(time.Minute)
+	_, err = cache.AlarmState(context.TODO(), "alarm1")
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, provider.calls["alarm1"])
+
+	now = now.Add(500 * time.Millisecond)
+	_, err = cache.AlarmState(context.TODO(), "alarm1")
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, provider.calls["alarm1"])
+
+	now = now.Add(500 * time.Millisecond)
+	_, err = cache.AlarmState(context.TODO(), "alarm1")
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, provider.calls["alarm1"])
+
+	now = now.Add(time.Second)
+	_, err = cache.AlarmState(context.TODO(), "alarm1")
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, provider.calls["alarm1"])
+
+	// The TTL applies again once the provider recovers
+	provider.err = nil
+	now = now.Add(time.Second)
+	state, err = cache.AlarmState(context.TODO(), "alarm1")
+	assert.Nil(t, err)
+	assert.Equal(t, types.StateValueAlarm, state)
+	assert.Equal(t, 5, provider.calls["alarm1"])
+}
+
+func TestAlarmStateBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, alarmStateBackoff(1))
+	assert.Equal(t, 2*time.Second, alarmStateBackoff(2))
+	assert.Equal(t, 8*time.Second, alarmStateBackoff(4))
+	assert.Equal(t, 5*time.Minute, alarmStateBackoff(20))
+	assert.Equal(t, 5*time.Minute, alarmStateBackoff(1000))
+}
+
+func TestIsThrottleError(t *testing.T) {
+	assert.False(t, isThrottleError(nil))
+	assert.False(t, isThrottleError(fmt.Errorf("anyError")))
+	assert.True(t, isThrottleError(throttleError{}))
+	assert.True(t, isThrottleError(fmt.Errorf("wrapped: %w", throttleError{})))
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	transitionTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	conditions := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, Reason: "OldReason", LastTransitionTime: transitionTime},
+	}
+
+	SetCondition(&conditions, "Ready", true, "NewReason", "message", 2)
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, "NewReason", conditions[0].Reason)
+	assert.Equal(t, "message", conditions[0].Message)
+	assert.Equal(t, int64(2), conditions[0].ObservedGeneration)
+	assert.Equal(t, transitionTime, conditions[0].LastTransitionTime)
+
+	SetCondition(&conditions, "Ready", false, "NotReady", "", 2)
+	assert.Equal(t, metav1.ConditionFalse, conditions[0].Status)
+	assert.NotEqual(t, transitionTime, conditions[0].LastTransitionTime)
+
+	SetCondition(&conditions, "Blocked", true, "Blocked", "", 2)
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, metav1.ConditionTrue, conditions[1].Status)
+}
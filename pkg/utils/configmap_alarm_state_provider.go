@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapAlarmStateProvider reads alarm states from the keys of a ConfigMap, so rollouts can be gated from inside
+// the cluster (e.g. by a canary job) without CloudWatch. Each key is an alarm name, and its value is the alarm state:
+// OK, ALARM or INSUFFICIENT_DATA.
+type ConfigMapAlarmStateProvider struct {
+	Client    client.Reader
+	Namespace string
+	Name      string
+}
+
+func (p *ConfigMapAlarmStateProvider) AlarmState(ctx context.Context, alarmName string) (types.StateValue, error) {
+	configMap := &v1.ConfigMap{}
+	if err := p.Client.Get(ctx, client.ObjectKey{Namespace: p.Namespace, Name: p.Name}, configMap); err != nil {
+		return "", err
+	}
+
+	value, ok := configMap.Data[alarmName]
+	if !ok {
+		return "", fmt.Errorf("alarm not found in ConfigMap %s/%s: %s", p.Namespace, p.Name, alarmName)
+	}
+	state := types.StateValue(value)
+	for _, knownState := range state.Values() {
+		if state == knownState {
+			return state, nil
+		}
+	}
+	return "", fmt.Errorf("invalid state %q for alarm %s in ConfigMap %s/%s", value, alarmName, p.Namespace, p.Name)
+}
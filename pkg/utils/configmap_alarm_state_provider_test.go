@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapAlarmStateProvider(t *testing.T) {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "zau-system", Name: "rollout-alarms"},
+		Data: map[string]string{
+			"canary":   "ALARM",
+			"health":   "OK",
+			"new":      "INSUFFICIENT_DATA",
+			"typo":     "alarm",
+			"zone-a-1": "OK",
+		},
+	}
+	tests := []struct {
+		name          string
+		configMapName string
+		alarmName     string
+		expectedState types.StateValue
+		expectError   bool
+	}{
+		{
+			name:          "alarm state",
+			configMapName: "rollout-alarms",
+			alarmName:     "canary",
+			expectedState: types.StateValueAlarm,
+		},
+		{
+			name:          "ok state",
+			configMapName: "rollout-alarms",
+			alarmName:     "health",
+			expectedState: types.StateValueOk,
+		},
+		{
+			name:          "insufficient data state",
+			configMapName: "rollout-alarms",
+			alarmName:     "new",
+			expectedState: types.StateValueInsufficientData,
+		},
+		{
+			name:          "invalid state",
+			configMapName: "rollout-alarms",
+			alarmName:     "typo",
+			expectError:   true,
+		},
+		{
+			name:          "missing alarm",
+			configMapName: "rollout-alarms",
+			alarmName:     "missing",
+			expectError:   true,
+		},
+		{
+			name:          "missing ConfigMap",
+			configMapName: "missing",
+			alarmName:     "canary",
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := ConfigMapAlarmStateProvider{
+				Client:    fake.NewClientBuilder().WithObjects(configMap).Build(),
+				Namespace: "zau-system",
+				Name:      tt.configMapName,
+			}
+			state, err := provider.AlarmState(context.TODO(), tt.alarmName)
+			if tt.expectError {
+				assert.NotNil(t, err)
+				assert.Equal(t, types.StateValue(""), state)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedState, state)
+			}
+		})
+	}
+}
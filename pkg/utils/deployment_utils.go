@@ -0,0 +1,169 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+Modifications Copyright 2022 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/davecgh/go-spew/spew"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// Methods below were copied/adapted from k8s directly because k8s.io/kubernetes
+// is not intended to be consumed as a module: https://github.com/kubernetes/kubernetes/issues/79384
+//
+// Source files:
+// https://github.com/kubernetes/kubernetes/blob/d7123a65248e25/pkg/controller/controller_utils.go
+// https://github.com/kubernetes/kubernetes/blob/d7123a65248e25/pkg/controller/deployment/util/deployment_util.go
+// https://github.com/kubernetes/kubernetes/blob/d7123a65248e25/pkg/controller/deployment/sync.go
+
+var (
+	ControllerKindDeployment = apps.SchemeGroupVersion.WithKind("Deployment")
+	ControllerKindRS         = apps.SchemeGroupVersion.WithKind("ReplicaSet")
+)
+
+const (
+	// RevisionAnnotation is the revision annotation of a deployment's replica sets which records its rollout sequence
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// DesiredReplicasAnnotation is the desired replicas for a deployment recorded as an annotation
+	// in its replica sets. Helps in separating scaling events from the rollout process and for
+	// determining if the new replica set for a deployment is really saturated.
+	DesiredReplicasAnnotation = "deployment.kubernetes.io/desired-replicas"
+	// MaxReplicasAnnotation is the maximum replicas a deployment can have at a given point, which
+	// is deployment.spec.replicas + maxSurge. Used by the underlying replica sets to estimate their
+	// proportions in case the deployment has surge replicas.
+	MaxReplicasAnnotation = "deployment.kubernetes.io/max-replicas"
+)
+
+// ComputeHash returns a hash value calculated from pod template and
+// a collisionCount to avoid hash collision. The hash will be safe encoded to
+// avoid bad words.
+func ComputeHash(template *v1.PodTemplateSpec, collisionCount *int32) string {
+	podTemplateSpecHasher := fnv.New32a()
+	deepHashObject(podTemplateSpecHasher, *template)
+
+	// Add collisionCount in the hash if it exists.
+	if collisionCount != nil {
+		collisionCountBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint32(collisionCountBytes, uint32(*collisionCount))
+		podTemplateSpecHasher.Write(collisionCountBytes)
+	}
+
+	return rand.SafeEncodeString(fmt.Sprint(podTemplateSpecHasher.Sum32()))
+}
+
+// deepHashObject writes specified object to hash using the spew library
+// which follows pointers and prints actual values of the nested objects
+// ensuring the hash does not change when a pointer changes.
+func deepHashObject(hasher hash.Hash, objectToWrite interface{}) {
+	hasher.Reset()
+	printer := spew.ConfigState{
+		Indent:         " ",
+		SortKeys:       true,
+		DisableMethods: true,
+		SpewKeys:       true,
+	}
+	printer.Fprintf(hasher, "%#v", objectToWrite)
+}
+
+// EqualIgnoreHash returns true if two given podTemplateSpec are equal, ignoring the diff in value of Labels[pod-template-hash]
+// We ignore pod-template-hash because:
+//  1. The hash result would be different upon podTemplateSpec API changes
+//     (e.g. the addition of a new field will cause the hash code to change)
+//  2. The deployment template won't have hash labels
+func EqualIgnoreHash(template1, template2 *v1.PodTemplateSpec) bool {
+	t1Copy := template1.DeepCopy()
+	t2Copy := template2.DeepCopy()
+	// Remove hash labels from template.Labels before comparing
+	delete(t1Copy.Labels, apps.DefaultDeploymentUniqueLabelKey)
+	delete(t2Copy.Labels, apps.DefaultDeploymentUniqueLabelKey)
+	return apiequality.Semantic.DeepEqual(t1Copy, t2Copy)
+}
+
+// Revision returns the revision number of the input object.
+func Revision(obj metav1.Object) (int64, error) {
+	v, ok := obj.GetAnnotations()[RevisionAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// MaxRevision finds the highest revision in the replica sets
+func MaxRevision(allRSs []*apps.ReplicaSet) int64 {
+	max := int64(0)
+	for _, rs := range allRSs {
+		if v, err := Revision(rs); err == nil && v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// NewReplicaSet returns the ReplicaSet, scaled to zero, that the deployment controller
+// would create for the current deployment's pod template, with the given revision.
+// The max replicas annotation doesn't account for surge, as the deployment must use
+// the Recreate strategy.
+func NewReplicaSet(d *apps.Deployment, revision int64) *apps.ReplicaSet {
+	newRSTemplate := *d.Spec.Template.DeepCopy()
+	podTemplateSpecHash := ComputeHash(&newRSTemplate, d.Status.CollisionCount)
+	newRSTemplate.Labels = cloneAndAddLabel(d.Spec.Template.Labels, apps.DefaultDeploymentUniqueLabelKey, podTemplateSpecHash)
+	newRSSelector := d.Spec.Selector.DeepCopy()
+	newRSSelector.MatchLabels = cloneAndAddLabel(newRSSelector.MatchLabels, apps.DefaultDeploymentUniqueLabelKey, podTemplateSpecHash)
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	return &apps.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            d.Name + "-" + podTemplateSpecHash,
+			Namespace:       d.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, ControllerKindDeployment)},
+			Labels:          newRSTemplate.Labels,
+			Annotations: map[string]string{
+				RevisionAnnotation:        strconv.FormatInt(revision, 10),
+				DesiredReplicasAnnotation: strconv.Itoa(int(replicas)),
+				MaxReplicasAnnotation:     strconv.Itoa(int(replicas)),
+			},
+		},
+		Spec: apps.ReplicaSetSpec{
+			Replicas:        new(int32),
+			MinReadySeconds: d.Spec.MinReadySeconds,
+			Selector:        newRSSelector,
+			Template:        newRSTemplate,
+		},
+	}
+}
+
+func cloneAndAddLabel(labels map[string]string, labelKey, labelValue string) map[string]string {
+	newLabels := map[string]string{}
+	for key, value := range labels {
+		newLabels[key] = value
+	}
+	newLabels[labelKey] = labelValue
+	return newLabels
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HealthGateRequest is the JSON body sent to a ZoneAwareUpdate HealthGate before each step.
+type HealthGateRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Zone      string `json:"zone"`
+	Step      int32  `json:"step"`
+	Revision  string `json:"revision"`
+}
+
+// HealthGateDecision is the outcome of a HealthGate request.
+type HealthGateDecision string
+
+const (
+	HealthGateProceed HealthGateDecision = "Proceed"
+	HealthGatePause   HealthGateDecision = "Pause"
+)
+
+type HealthGateChecker interface {
+	Check(ctx context.Context, url string, request HealthGateRequest) (HealthGateDecision, error)
+}
+
+// HTTPHealthGateChecker posts the HealthGateRequest to the HealthGate URL. 2xx responses proceed,
+// 409 Conflict pauses, and other responses are returned as errors.
+type HTTPHealthGateChecker struct {
+	Client *http.Client
+}
+
+func (c *HTTPHealthGateChecker) Check(ctx context.Context, url string, request HealthGateRequest) (HealthGateDecision, error) {
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return HealthGateProceed, nil
+	case resp.StatusCode == http.StatusConflict:
+		return HealthGatePause, nil
+	default:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return "", fmt.Errorf("health gate returned %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+}
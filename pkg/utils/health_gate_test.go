@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHealthGateChecker(t *testing.T) {
+	request := HealthGateRequest{Namespace: "default", Name: "zau", Zone: "zone-a", Step: 2, Revision: "rev-2"}
+	tests := []struct {
+		name             string
+		statusCode       int
+		expectedDecision HealthGateDecision
+		expectError      bool
+	}{
+		{
+			name:             "proceed on 200",
+			statusCode:       http.StatusOK,
+			expectedDecision: HealthGateProceed,
+		},
+		{
+			name:             "proceed on 204",
+			statusCode:       http.StatusNoContent,
+			expectedDecision: HealthGateProceed,
+		},
+		{
+			name:             "pause on 409",
+			statusCode:       http.StatusConflict,
+			expectedDecision: HealthGatePause,
+		},
+		{
+			name:        "error on 503",
+			statusCode:  http.StatusServiceUnavailable,
+			expectError: true,
+		},
+		{
+			name:        "error on 404",
+			statusCode:  http.StatusNotFound,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				received := HealthGateRequest{}
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+				assert.Equal(t, request, received)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			checker := HTTPHealthGateChecker{}
+			decision, err := checker.Check(context.TODO(), server.URL, request)
+			if tt.expectError {
+				assert.NotNil(t, err)
+				assert.Equal(t, HealthGateDecision(""), decision)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedDecision, decision)
+			}
+		})
+	}
+
+	t.Run("error on unreachable endpoint", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		checker := HTTPHealthGateChecker{}
+		_, err := checker.Check(context.TODO(), server.URL, request)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"strings"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+)
+
+// ZonePlaceholder is replaced by the zone being updated in the pause alarm names and query.
+const ZonePlaceholder = "{zone}"
+
+// PauseAlarmNames returns the pause alarms to check while the given zone is updated: the PauseRolloutAlarm and
+// PauseRolloutAlarms, with the ZonePlaceholder replaced by the zone, and the zone alarm in the ZonePauseRolloutAlarms.
+// Alarms with the ZonePlaceholder are skipped when no zone is given. Duplicates are removed.
+func PauseAlarmNames(zau *opsv1.ZoneAwareUpdate, zone string) []string {
+	alarms := []string{}
+	add := func(alarm string) {
+		if alarm != "" && !ContainsString(alarms, alarm) {
+			alarms = append(alarms, alarm)
+		}
+	}
+
+	for _, alarm := range append([]string{zau.Spec.PauseRolloutAlarm}, zau.Spec.PauseRolloutAlarms...) {
+		if strings.Contains(alarm, ZonePlaceholder) {
+			if zone == "" {
+				continue
+			}
+			alarm = strings.ReplaceAll(alarm, ZonePlaceholder, zone)
+		}
+		add(alarm)
+	}
+	if zone != "" {
+		add(zau.Spec.ZonePauseRolloutAlarms[zone])
+	}
+	return alarms
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+)
+
+func TestPauseAlarmNames(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           opsv1.ZoneAwareUpdateSpec
+		zone           string
+		expectedAlarms []string
+	}{
+		{
+			name:           "no alarms",
+			zone:           "zone-a",
+			expectedAlarms: []string{},
+		},
+		{
+			name: "global alarms without duplicates",
+			spec: opsv1.ZoneAwareUpdateSpec{
+				PauseRolloutAlarm:  "global",
+				PauseRolloutAlarms: []string{"canary", "global"},
+			},
+			zone:           "zone-a",
+			expectedAlarms: []string{"global", "canary"},
+		},
+		{
+			name: "zone placeholder replaced by the zone",
+			spec: opsv1.ZoneAwareUpdateSpec{
+				PauseRolloutAlarm:  "global",
+				PauseRolloutAlarms: []string{"canary-{zone}"},
+			},
+			zone:           "zone-a",
+			expectedAlarms: []string{"global", "canary-zone-a"},
+		},
+		{
+			name: "zone placeholder skipped without zone",
+			spec: opsv1.ZoneAwareUpdateSpec{
+				PauseRolloutAlarm:      "canary-{zone}",
+				PauseRolloutAlarms:     []string{"global"},
+				ZonePauseRolloutAlarms: map[string]string{"zone-a": "health-a"},
+			},
+			expectedAlarms: []string{"global"},
+		},
+		{
+			name: "zone alarm from the zone map",
+			spec: opsv1.ZoneAwareUpdateSpec{
+				PauseRolloutAlarm:      "global",
+				ZonePauseRolloutAlarms: map[string]string{"zone-a": "health-a", "zone-b": "health-b"},
+			},
+			zone:           "zone-b",
+			expectedAlarms: []string{"global", "health-b"},
+		},
+		{
+			name: "zone missing from the zone map",
+			spec: opsv1.ZoneAwareUpdateSpec{
+				ZonePauseRolloutAlarms: map[string]string{"zone-a": "health-a"},
+			},
+			zone:           "zone-c",
+			expectedAlarms: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zau := &opsv1.ZoneAwareUpdate{Spec: tt.spec}
+			assert.Equal(t, tt.expectedAlarms, PauseAlarmNames(zau, tt.zone))
+		})
+	}
+}
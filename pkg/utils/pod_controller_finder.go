@@ -53,6 +53,7 @@ func Finders() []PodControllerFinder {
 
 var (
 	ControllerKindSS = apps.SchemeGroupVersion.WithKind("StatefulSet")
+	ControllerKindDS = apps.SchemeGroupVersion.WithKind("DaemonSet")
 )
 
 // getPodStatefulSet returns the statefulset referenced by the provided controllerRef.
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// QueryStateProvider returns the state of a query compared with a threshold, using the CloudWatch alarm states:
+// ALARM when the query is breaching, OK when it's not, and INSUFFICIENT_DATA when the query has no result.
+type QueryStateProvider interface {
+	QueryState(ctx context.Context, query string, threshold float64) (types.StateValue, error)
+}
+
+// PrometheusQueryStateProvider evaluates PromQL queries with the Prometheus instant query API.
+// The query is breaching when any of its samples is greater than the threshold.
+type PrometheusQueryStateProvider struct {
+	Address string
+	Client  *http.Client
+}
+
+type prometheusQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (p *PrometheusQueryStateProvider) QueryState(ctx context.Context, query string, threshold float64) (types.StateValue, error) {
+	values, err := p.query(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return types.StateValueInsufficientData, nil
+	}
+	for _, value := range values {
+		if value > threshold {
+			return types.StateValueAlarm, nil
+		}
+	}
+	return types.StateValueOk, nil
+}
+
+// query returns the values of the samples returned by the query.
+func (p *PrometheusQueryStateProvider) query(ctx context.Context, query string) ([]float64, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	queryURL := strings.TrimSuffix(p.Address, "/") + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &prometheusQueryResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("failed to decode prometheus response (status code %d): %w", resp.StatusCode, err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s: %s", response.ErrorType, response.Error)
+	}
+
+	switch response.Data.ResultType {
+	case "vector":
+		var samples []struct {
+			Value []json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(response.Data.Result, &samples); err != nil {
+			return nil, err
+		}
+		values := make([]float64, 0, len(samples))
+		for _, sample := range samples {
+			value, err := parseSampleValue(sample.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case "scalar":
+		var sample []json.RawMessage
+		if err := json.Unmarshal(response.Data.Result, &sample); err != nil {
+			return nil, err
+		}
+		value, err := parseSampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{value}, nil
+	default:
+		return nil, fmt.Errorf("unsupported prometheus result type %q", response.Data.ResultType)
+	}
+}
+
+// parseSampleValue parses a [<unix_time>, "<value>"] sample.
+func parseSampleValue(sample []json.RawMessage) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid prometheus sample: %s", sample)
+	}
+	var value string
+	if err := json.Unmarshal(sample[1], &value); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusQueryStateProvider(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		response      string
+		expectedState types.StateValue
+		expectError   bool
+	}{
+		{
+			name:          "vector above the threshold",
+			statusCode:    http.StatusOK,
+			response:      `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"zone":"a"},"value":[1672531200,"0.5"]},{"metric":{"zone":"b"},"value":[1672531200,"2"]}]}}`,
+			expectedState: types.StateValueAlarm,
+		},
+		{
+			name:          "vector below the threshold",
+			statusCode:    http.StatusOK,
+			response:      `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1672531200,"1"]}]}}`,
+			expectedState: types.StateValueOk,
+		},
+		{
+			name:          "empty vector",
+			statusCode:    http.StatusOK,
+			response:      `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			expectedState: types.StateValueInsufficientData,
+		},
+		{
+			name:          "scalar above the threshold",
+			statusCode:    http.StatusOK,
+			response:      `{"status":"success","data":{"resultType":"scalar","result":[1672531200,"3"]}}`,
+			expectedState: types.StateValueAlarm,
+		},
+		{
+			name:        "query error",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			expectError: true,
+		},
+		{
+			name:        "unsupported result type",
+			statusCode:  http.StatusOK,
+			response:    `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			expectError: true,
+		},
+		{
+			name:        "invalid response",
+			statusCode:  http.StatusBadGateway,
+			response:    `bad gateway`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/query", r.URL.Path)
+				assert.Equal(t, "error_rate", r.URL.Query().Get("query"))
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			provider := PrometheusQueryStateProvider{Address: server.URL}
+			state, err := provider.QueryState(context.TODO(), "error_rate", 1)
+			if tt.expectError {
+				assert.NotNil(t, err)
+				assert.Equal(t, types.StateValue(""), state)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedState, state)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apps "k8s.io/api/apps/v1"
+)
+
+const (
+	// Annotation with the update strategy a StatefulSet had before being switched to OnDelete
+	// for a ZoneAwareUpdate, in JSON.
+	OriginalUpdateStrategyAnnotation = "zonecontrol.k8s.aws/original-update-strategy"
+
+	// Label with the name of the ZoneAwareUpdate rolling out a StatefulSet. The StatefulSet webhook only
+	// receives the StatefulSets with this label.
+	ZoneAwareUpdateLabel = "zonecontrol.k8s.aws/zone-aware-update"
+)
+
+// ForceOnDeleteUpdateStrategy switches the StatefulSet to the OnDelete update strategy, saving the original
+// strategy in the OriginalUpdateStrategyAnnotation. It returns false if the StatefulSet already uses OnDelete.
+func ForceOnDeleteUpdateStrategy(sts *apps.StatefulSet) (bool, error) {
+	if sts.Spec.UpdateStrategy.Type == apps.OnDeleteStatefulSetStrategyType {
+		return false, nil
+	}
+
+	original, err := json.Marshal(sts.Spec.UpdateStrategy)
+	if err != nil {
+		return false, err
+	}
+	if sts.Annotations == nil {
+		sts.Annotations = map[string]string{}
+	}
+	sts.Annotations[OriginalUpdateStrategyAnnotation] = string(original)
+	sts.Spec.UpdateStrategy = apps.StatefulSetUpdateStrategy{Type: apps.OnDeleteStatefulSetStrategyType}
+	return true, nil
+}
+
+// RestoreUpdateStrategy restores the update strategy saved by ForceOnDeleteUpdateStrategy, and removes
+// the OriginalUpdateStrategyAnnotation. It returns false if the StatefulSet has no saved strategy.
+func RestoreUpdateStrategy(sts *apps.StatefulSet) (bool, error) {
+	original, ok := sts.Annotations[OriginalUpdateStrategyAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	var strategy apps.StatefulSetUpdateStrategy
+	if err := json.Unmarshal([]byte(original), &strategy); err != nil {
+		return false, fmt.Errorf("invalid %s annotation: %w", OriginalUpdateStrategyAnnotation, err)
+	}
+	delete(sts.Annotations, OriginalUpdateStrategyAnnotation)
+	sts.Spec.UpdateStrategy = strategy
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestForceOnDeleteAndRestoreUpdateStrategy(t *testing.T) {
+	rollingUpdate := apps.StatefulSetUpdateStrategy{
+		Type:          apps.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{Partition: pointer.Int32(2)},
+	}
+	sts := &apps.StatefulSet{Spec: apps.StatefulSetSpec{UpdateStrategy: *rollingUpdate.DeepCopy()}}
+
+	changed, err := ForceOnDeleteUpdateStrategy(sts)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, apps.StatefulSetUpdateStrategy{Type: apps.OnDeleteStatefulSetStrategyType}, sts.Spec.UpdateStrategy)
+	assert.Contains(t, sts.Annotations, OriginalUpdateStrategyAnnotation)
+
+	changed, err = ForceOnDeleteUpdateStrategy(sts)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = RestoreUpdateStrategy(sts)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, rollingUpdate, sts.Spec.UpdateStrategy)
+	assert.NotContains(t, sts.Annotations, OriginalUpdateStrategyAnnotation)
+
+	changed, err = RestoreUpdateStrategy(sts)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestRestoreUpdateStrategyInvalidAnnotation(t *testing.T) {
+	sts := &apps.StatefulSet{}
+	sts.Annotations = map[string]string{OriginalUpdateStrategyAnnotation: "not-json"}
+
+	_, err := RestoreUpdateStrategy(sts)
+	assert.Error(t, err)
+}
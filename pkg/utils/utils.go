@@ -18,6 +18,8 @@ limitations under the License.
 package utils
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -25,10 +27,40 @@ func IsRunningAndReady(pod *v1.Pod) bool {
 	return pod.Status.Phase == v1.PodRunning && IsPodReady(pod)
 }
 
+// GetPodAvailableTime returns the time when a ready pod becomes available, after being ready for minReadyDuration.
+// Returns the zero time if the pod ready transition time is unknown.
+func GetPodAvailableTime(pod *v1.Pod, minReadyDuration time.Duration) time.Time {
+	condition := GetPodReadyCondition(pod.Status)
+	if condition == nil || condition.LastTransitionTime.IsZero() {
+		return time.Time{}
+	}
+	return condition.LastTransitionTime.Add(minReadyDuration)
+}
+
+// GetPodNotReadyTime returns the time since when the pod is not ready, or its creation time
+// if the pod never reported the ready condition.
+func GetPodNotReadyTime(pod *v1.Pod) time.Time {
+	condition := GetPodReadyCondition(pod.Status)
+	if condition == nil || condition.LastTransitionTime.IsZero() {
+		return pod.CreationTimestamp.Time
+	}
+	return condition.LastTransitionTime.Time
+}
+
 func IsTerminating(pod *v1.Pod) bool {
 	return pod.DeletionTimestamp != nil
 }
 
+// ContainsString returns true if the slice contains the string.
+func ContainsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 // Methods below were copied/adapted from k8s directly because k8s.io/kubernetes
 // is not intended to be consumed as a module: https://github.com/kubernetes/kubernetes/issues/79384
 //
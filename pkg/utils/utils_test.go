@@ -2,10 +2,12 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestIsRunningAndReady(t *testing.T) {
@@ -82,3 +84,93 @@ func TestIsRunningAndReady(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodAvailableTime(t *testing.T) {
+	readyTime := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want time.Time
+	}{
+		{
+			name: "pod with ready transition time",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               corev1.PodReady,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(readyTime),
+						},
+					},
+				},
+			},
+			want: readyTime.Add(30 * time.Second),
+		},
+		{
+			name: "pod without ready transition time",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: time.Time{},
+		},
+		{
+			name: "pod without ready condition",
+			pod:  &corev1.Pod{},
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetPodAvailableTime(tt.pod, 30*time.Second)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetPodNotReadyTime(t *testing.T) {
+	creationTime := time.Date(2022, 1, 1, 9, 0, 0, 0, time.UTC)
+	notReadyTime := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want time.Time
+	}{
+		{
+			name: "pod with not ready transition time",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(creationTime)},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               corev1.PodReady,
+							Status:             corev1.ConditionFalse,
+							LastTransitionTime: metav1.NewTime(notReadyTime),
+						},
+					},
+				},
+			},
+			want: notReadyTime,
+		},
+		{
+			name: "pod without ready condition",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(creationTime)},
+			},
+			want: creationTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetPodNotReadyTime(tt.pod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
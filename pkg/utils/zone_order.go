@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"sort"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+)
+
+// GetZoneOrder returns the order in which zones are updated for the given update revision.
+// The order is computed once per revision and then kept in the ZAU status, so it doesn't change
+// while the revision is being rolled out (e.g. as the least loaded zone gets updated).
+// Zones with old pods missing from the stored order (e.g. a new zone) are appended alphabetically.
+func GetZoneOrder(zau *opsv1.ZoneAwareUpdate, updateRevision string, zonePodsCount map[string]int32) []string {
+	if len(zau.Status.ZoneOrder) > 0 && zau.Status.UpdateRevision == updateRevision {
+		return appendMissingZones(zau.Status.ZoneOrder, sortedZones(zonePodsCount))
+	}
+
+	// A new revision started before the previous rollout finished (e.g. a rollback) keeps
+	// the previous order, which is reversed while rolling back so the most recently updated
+	// pods are replaced first.
+	if len(zau.Status.ZoneOrder) > 0 && zau.Status.UpdateStep > 0 {
+		return appendMissingZones(zau.Status.ZoneOrder, sortedZones(zonePodsCount))
+	}
+
+	remaining := map[string]int32{}
+	for zone, count := range zonePodsCount {
+		remaining[zone] = count
+	}
+
+	order := []string{}
+	for _, zone := range zau.Spec.ZoneOrder {
+		if _, ok := remaining[zone]; ok {
+			order = append(order, zone)
+			delete(remaining, zone)
+		}
+	}
+
+	zones := sortedZones(remaining)
+	if zau.Spec.ZoneOrderStrategy == opsv1.LeastLoadedFirstZoneOrder {
+		sort.SliceStable(zones, func(i, j int) bool {
+			return remaining[zones[i]] < remaining[zones[j]]
+		})
+	}
+	order = append(order, zones...)
+
+	if zau.Spec.ZoneOrderStrategy == opsv1.RotatingZoneOrder && len(zau.Status.ZoneOrder) > 0 {
+		previousFirst := zau.Status.ZoneOrder[0]
+		for i, zone := range order {
+			if zone == previousFirst {
+				next := (i + 1) % len(order)
+				order = append(append([]string{}, order[next:]...), order[:next]...)
+				break
+			}
+		}
+	}
+
+	return order
+}
+
+func appendMissingZones(order []string, zones []string) []string {
+	result := append([]string{}, order...)
+	for _, zone := range zones {
+		if !ContainsString(result, zone) {
+			result = append(result, zone)
+		}
+	}
+	return result
+}
+
+func sortedZones(zonePodsCount map[string]int32) []string {
+	zones := make([]string, 0, len(zonePodsCount))
+	for zone := range zonePodsCount {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
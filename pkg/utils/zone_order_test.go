@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+)
+
+func TestGetZoneOrder(t *testing.T) {
+	zonePodsCount := map[string]int32{
+		"zone-a": int32(3),
+		"zone-b": int32(1),
+		"zone-c": int32(2),
+	}
+	tests := []struct {
+		name           string
+		spec           opsv1.ZoneAwareUpdateSpec
+		status         opsv1.ZoneAwareUpdateStatus
+		updateRevision string
+		expectedOrder  []string
+	}{
+		{
+			name:           "alphabetical by default",
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-a", "zone-b", "zone-c"},
+		},
+		{
+			name:           "least loaded first",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrderStrategy: opsv1.LeastLoadedFirstZoneOrder},
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-b", "zone-c", "zone-a"},
+		},
+		{
+			name:           "explicit zone order followed by the strategy",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrder: []string{"zone-c", "zone-x"}, ZoneOrderStrategy: opsv1.AlphabeticalZoneOrder},
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-c", "zone-a", "zone-b"},
+		},
+		{
+			name:           "rotating without previous revision",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrderStrategy: opsv1.RotatingZoneOrder},
+			updateRevision: "rev-1",
+			expectedOrder:  []string{"zone-a", "zone-b", "zone-c"},
+		},
+		{
+			name:           "rotating starts from the zone after the previous first zone",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrderStrategy: opsv1.RotatingZoneOrder},
+			status:         opsv1.ZoneAwareUpdateStatus{UpdateRevision: "rev-1", ZoneOrder: []string{"zone-c", "zone-a", "zone-b"}},
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-a", "zone-b", "zone-c"},
+		},
+		{
+			name:           "rotating keeps the previous order when the previous rollout didn't finish",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrderStrategy: opsv1.RotatingZoneOrder},
+			status:         opsv1.ZoneAwareUpdateStatus{UpdateRevision: "rev-1", UpdateStep: 2, ZoneOrder: []string{"zone-c", "zone-a", "zone-b"}},
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-c", "zone-a", "zone-b"},
+		},
+		{
+			name:           "least loaded keeps the previous order when the previous rollout didn't finish",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrderStrategy: opsv1.LeastLoadedFirstZoneOrder},
+			status:         opsv1.ZoneAwareUpdateStatus{UpdateRevision: "rev-1", UpdateStep: 1, ZoneOrder: []string{"zone-a", "zone-c"}},
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-a", "zone-c", "zone-b"},
+		},
+		{
+			name:           "order is kept for the same revision",
+			spec:           opsv1.ZoneAwareUpdateSpec{ZoneOrderStrategy: opsv1.LeastLoadedFirstZoneOrder},
+			status:         opsv1.ZoneAwareUpdateStatus{UpdateRevision: "rev-2", ZoneOrder: []string{"zone-c", "zone-a"}},
+			updateRevision: "rev-2",
+			expectedOrder:  []string{"zone-c", "zone-a", "zone-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zau := &opsv1.ZoneAwareUpdate{Spec: tt.spec, Status: tt.status}
+			assert.Equal(t, tt.expectedOrder, GetZoneOrder(zau, tt.updateRevision, zonePodsCount))
+		})
+	}
+}
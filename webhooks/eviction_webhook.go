@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/util/dryrun"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -66,9 +67,10 @@ var EvictionsRetry = wait.Backoff{
 }
 
 type PodEvictionHandler struct {
-	Client  client.Client
-	Logger  logr.Logger
-	decoder *admission.Decoder
+	Client   client.Client
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	decoder  *admission.Decoder
 }
 
 func (h *PodEvictionHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
@@ -171,11 +173,15 @@ func (h *PodEvictionHandler) handler(ctx context.Context, req admission.Request)
 
 	if err != nil {
 		h.Logger.Error(err, "Denying pod eviction", "pod", pod.Name)
+		message := h.evictionDeniedMessage(ctx, pod, zdb, err)
 		if dryRun {
 			h.Logger.Info("DryRun option enabled, allowing eviction request", "pod", pod.Name)
+			h.Recorder.Eventf(zdb, v1.EventTypeNormal, "DryRun", "DryRun option enabled, allowing eviction. %s", message)
 			return admission.Allowed(""), "DryRun"
 		}
-		return admission.Denied(fmt.Sprintf("denying pod eviction for %s", pod.Name)), "DeniedByZdb"
+		h.Recorder.Event(zdb, v1.EventTypeWarning, "EvictionDenied", message)
+		h.Recorder.Event(pod, v1.EventTypeWarning, "EvictionDenied", message)
+		return admission.Denied(fmt.Sprintf("denying pod eviction for %s by ZoneDisruptionBudget %s", pod.Name, zdb.Name)), "DeniedByZdb"
 	}
 
 	return admission.Allowed(""), "DisruptionAllowed"
@@ -248,6 +254,23 @@ func (h *PodEvictionHandler) checkAndDecrement(ctx context.Context, pod *v1.Pod,
 	return nil
 }
 
+// evictionDeniedMessage describes why the eviction was denied, with the pod zone and the ZDB counts.
+func (h *PodEvictionHandler) evictionDeniedMessage(ctx context.Context, pod *v1.Pod, zdb *opsv1.ZoneDisruptionBudget, err error) string {
+	zone, zoneErr := h.getZone(ctx, pod)
+	if zoneErr != nil {
+		zone = "unknown"
+	}
+	unhealthyZones := 0
+	for _, count := range zdb.Status.CurrentUnhealthy {
+		if count > 0 {
+			unhealthyZones++
+		}
+	}
+	return fmt.Sprintf("Eviction of pod %s in zone %s denied by ZoneDisruptionBudget %s: %d disruptions allowed "+
+		"and %d unhealthy pods in the zone, %d zones with unhealthy pods: %v", pod.Name, zone, zdb.Name,
+		zdb.Status.DisruptionsAllowed[zone], zdb.Status.CurrentUnhealthy[zone], unhealthyZones, err)
+}
+
 func canIgnoreZDB(pod *v1.Pod) bool {
 	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed ||
 		pod.Status.Phase == v1.PodPending || !pod.ObjectMeta.DeletionTimestamp.IsZero() {
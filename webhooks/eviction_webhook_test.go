@@ -1,6 +1,8 @@
 package webhook
 
 import (
+	"time"
+
 	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -8,6 +10,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Eviction Webhook", func() {
@@ -36,6 +39,18 @@ var _ = Describe("Eviction Webhook", func() {
 
 			zdb = testUtils.GetZdb(zdb.Name)
 			Expect(len(zdb.Status.DisruptedPods)).Should(Equal(0))
+
+			Eventually(func() []string {
+				events := &v1.EventList{}
+				Expect(k8sClient.List(ctx, events, client.InNamespace(pod.Namespace))).Should(Succeed())
+				messages := []string{}
+				for _, event := range events.Items {
+					if event.InvolvedObject.Name == pod.Name && event.Reason == "EvictionDenied" {
+						messages = append(messages, event.Message)
+					}
+				}
+				return messages
+			}, 10*time.Second, 250*time.Millisecond).Should(ContainElement(ContainSubstring("in zone az-1")))
 		})
 
 		It("Should allow evictions if the pod is not running", func() {
@@ -0,0 +1,111 @@
+/*
+Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	apps "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+	"github.com/aws/zone-aware-controllers-for-k8s/pkg/utils"
+)
+
+// The StatefulSet Mutating Webhook keeps StatefulSets rolled out by a ZoneAwareUpdate on the OnDelete
+// update strategy, so their pods are not all updated at once by the StatefulSet controller. The ZAU
+// controller switches them to OnDelete and labels them with the ZoneAwareUpdateLabel, and the webhook
+// only receives labeled StatefulSets, failing open. The original strategy is saved in an annotation,
+// and restored by the ZAU controller when the ZAU is deleted.
+
+//+kubebuilder:webhook:path=/mutate-statefulset-v1,mutating=true,failurePolicy=ignore,groups=apps,resources=statefulsets,verbs=create;update,versions=v1,name=statefulset.zone-aware-controllers.svc,sideEffects=None,admissionReviewVersions={v1,v1beta1}
+
+type StatefulSetUpdateStrategyHandler struct {
+	Client  client.Client
+	Logger  logr.Logger
+	decoder *admission.Decoder
+}
+
+func (h *StatefulSetUpdateStrategyHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.AdmissionRequest.Operation != admissionv1.Create && req.AdmissionRequest.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	sts := &apps.StatefulSet{}
+	if err := h.decoder.Decode(req, sts); err != nil {
+		h.Logger.Error(err, "Failed to decode StatefulSet", "name", req.Name, "namespace", req.Namespace)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if sts.Spec.UpdateStrategy.Type == apps.OnDeleteStatefulSetStrategyType {
+		return admission.Allowed("")
+	}
+
+	zau, err := h.getZauForStatefulSet(ctx, req.Namespace, sts)
+	if err != nil {
+		h.Logger.Error(err, "Failed to get ZAU", "sts", sts.Name)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if zau == nil {
+		return admission.Allowed("")
+	}
+
+	if _, err := utils.ForceOnDeleteUpdateStrategy(sts); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	marshaled, err := json.Marshal(sts)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	h.Logger.Info("Switching StatefulSet update strategy to OnDelete", "sts", sts.Name, "zau", zau.Name)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+func (h *StatefulSetUpdateStrategyHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// getZauForStatefulSet returns the ZAU rolling out the StatefulSet, or nil if none exists.
+// ZAUs being deleted are ignored, so the original update strategy can be restored.
+func (h *StatefulSetUpdateStrategyHandler) getZauForStatefulSet(ctx context.Context, namespace string, sts *apps.StatefulSet) (*opsv1.ZoneAwareUpdate, error) {
+	zauList := &opsv1.ZoneAwareUpdateList{}
+	if err := h.Client.List(ctx, zauList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	for i := range zauList.Items {
+		zau := &zauList.Items[i]
+		if !zau.DeletionTimestamp.IsZero() {
+			continue
+		}
+		targets, err := targetStatefulSets(zau, []apps.StatefulSet{*sts})
+		if err != nil {
+			h.Logger.Info("Ignoring ZAU with invalid StatefulSet selector", "zau", zau.Name, "error", err.Error())
+			continue
+		}
+		if utils.ContainsString(targets, sts.Name) {
+			return zau, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"github.com/aws/zone-aware-controllers-for-k8s/pkg/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("StatefulSet Mutating Webhook", func() {
+	Context("When a ZAU targets the StatefulSet", func() {
+		It("Should switch the update strategy to OnDelete", func() {
+			Expect(k8sClient.Create(ctx, newZau("sts-mutate", "sts-mutate-ss", "2.0", intstr.FromInt(1)))).Should(Succeed())
+
+			// Only the StatefulSets labeled by the ZAU controller are received by the webhook
+			ss := testUtils.CreateStatefulSet(3, "sts-mutate")
+			Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.RollingUpdateStatefulSetStrategyType))
+			ss.Labels = map[string]string{utils.ZoneAwareUpdateLabel: "sts-mutate"}
+			Expect(k8sClient.Update(ctx, ss)).Should(Succeed())
+			Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.OnDeleteStatefulSetStrategyType))
+			Expect(ss.Annotations).Should(HaveKey(utils.OriginalUpdateStrategyAnnotation))
+
+			restored, err := utils.RestoreUpdateStrategy(ss)
+			Expect(err).Should(BeNil())
+			Expect(restored).Should(BeTrue())
+			Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.RollingUpdateStatefulSetStrategyType))
+		})
+	})
+
+	Context("When no ZAU targets the StatefulSet", func() {
+		It("Should keep the update strategy", func() {
+			ss := testUtils.CreateStatefulSet(3, "sts-no-zau")
+			Expect(ss.Spec.UpdateStrategy.Type).Should(Equal(apps.RollingUpdateStatefulSetStrategyType))
+			Expect(ss.Annotations).ShouldNot(HaveKey(utils.OriginalUpdateStrategyAnnotation))
+		})
+	})
+})
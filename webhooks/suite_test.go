@@ -112,8 +112,17 @@ var _ = BeforeSuite(func() {
 
 	hookServer := mgr.GetWebhookServer()
 	hookServer.Register("/pod-eviction-v1", &webhook.Admission{Handler: &PodEvictionHandler{
+		Client:   mgr.GetClient(),
+		Logger:   ctrl.Log.WithName("eviction-webhook"),
+		Recorder: mgr.GetEventRecorderFor("eviction-webhook"),
+	}})
+	hookServer.Register("/validate-zau-v1", &webhook.Admission{Handler: &ZoneAwareUpdateValidator{
+		Client: mgr.GetClient(),
+		Logger: ctrl.Log.WithName("zau-webhook"),
+	}})
+	hookServer.Register("/mutate-statefulset-v1", &webhook.Admission{Handler: &StatefulSetUpdateStrategyHandler{
 		Client: mgr.GetClient(),
-		Logger: ctrl.Log.WithName("eviction-webhook"),
+		Logger: ctrl.Log.WithName("statefulset-webhook"),
 	}})
 
 	//+kubebuilder:scaffold:webhook
@@ -0,0 +1,259 @@
+/*
+Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+	"github.com/aws/zone-aware-controllers-for-k8s/pkg/utils"
+)
+
+// The ZoneAwareUpdate Validating Webhook rejects ZAUs that can't be reconciled, so bad specs are
+// reported when they are applied instead of at reconcile time.
+
+//+kubebuilder:webhook:path=/validate-zau-v1,mutating=false,failurePolicy=fail,groups=zonecontrol.k8s.aws,resources=zoneawareupdates,verbs=create;update,versions=v1,name=zau.zone-aware-controllers.svc,sideEffects=None,admissionReviewVersions={v1,v1beta1}
+
+type ZoneAwareUpdateValidator struct {
+	Client  client.Client
+	Logger  logr.Logger
+	decoder *admission.Decoder
+}
+
+func (v *ZoneAwareUpdateValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.AdmissionRequest.Operation != admissionv1.Create && req.AdmissionRequest.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	zau := &opsv1.ZoneAwareUpdate{}
+	if err := v.decoder.Decode(req, zau); err != nil {
+		v.Logger.Error(err, "Failed to decode ZoneAwareUpdate", "name", req.Name, "namespace", req.Namespace)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// ZAUs being deleted, and updates that don't change the spec (e.g. finalizers), are always allowed,
+	// so ZAUs created before the webhook can still be reconciled and deleted.
+	if !zau.DeletionTimestamp.IsZero() {
+		return admission.Allowed("")
+	}
+	var oldZau *opsv1.ZoneAwareUpdate
+	if req.AdmissionRequest.Operation == admissionv1.Update {
+		oldZau = &opsv1.ZoneAwareUpdate{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldZau); err != nil {
+			v.Logger.Error(err, "Failed to decode old ZoneAwareUpdate", "name", req.Name, "namespace", req.Namespace)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(oldZau.Spec, zau.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	if err := validateZauSpec(zau); err != nil {
+		v.Logger.Info("Denying invalid ZoneAwareUpdate", "zau", zau.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+
+	// The deployment controller spreads the max surge of RollingUpdate deployments across the old and new
+	// ReplicaSets, even when paused. Deployments created after the ZAU are checked by the controller.
+	if zau.Spec.Workload != nil && zau.Spec.Workload.Kind == utils.ControllerKindDeployment.Kind {
+		deployment := &apps.Deployment{}
+		err := v.Client.Get(ctx, types.NamespacedName{Namespace: zau.Namespace, Name: zau.Spec.Workload.Name}, deployment)
+		if err != nil && !apierrors.IsNotFound(err) {
+			v.Logger.Error(err, "Failed to get Deployment", "name", zau.Spec.Workload.Name, "namespace", zau.Namespace)
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if err == nil && deployment.Spec.Strategy.Type != apps.RecreateDeploymentStrategyType {
+			return admission.Denied(fmt.Sprintf("Deployment %s must use the %s strategy", deployment.Name, apps.RecreateDeploymentStrategyType))
+		}
+	}
+
+	stsList := &apps.StatefulSetList{}
+	if err := v.Client.List(ctx, stsList, client.InNamespace(zau.Namespace)); err != nil {
+		v.Logger.Error(err, "Failed to list StatefulSets", "namespace", zau.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	targets, err := targetStatefulSets(zau, stsList.Items)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	warnings := statefulSetWarnings(targets, stsList.Items)
+
+	// Duplicate targets are only checked when they change
+	if oldZau != nil {
+		if oldTargets, err := targetStatefulSets(oldZau, stsList.Items); err == nil && reflect.DeepEqual(oldTargets, targets) {
+			return admission.Allowed("").WithWarnings(warnings...)
+		}
+	}
+
+	zauList := &opsv1.ZoneAwareUpdateList{}
+	if err := v.Client.List(ctx, zauList, client.InNamespace(zau.Namespace)); err != nil {
+		v.Logger.Error(err, "Failed to list ZoneAwareUpdates", "namespace", zau.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	for i := range zauList.Items {
+		other := &zauList.Items[i]
+		if other.Name == zau.Name {
+			continue
+		}
+		otherTargets, err := targetStatefulSets(other, stsList.Items)
+		if err != nil {
+			continue
+		}
+		for _, name := range targets {
+			if utils.ContainsString(otherTargets, name) {
+				return admission.Denied(fmt.Sprintf("StatefulSet %s is already rolled out by ZoneAwareUpdate %s", name, other.Name))
+			}
+		}
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+func (v *ZoneAwareUpdateValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// validateZauSpec returns an error if the ZAU fields used to compute the update steps are invalid.
+func validateZauSpec(zau *opsv1.ZoneAwareUpdate) error {
+	factor, err := strconv.ParseFloat(zau.Spec.ExponentialFactor, 64)
+	if err != nil {
+		return fmt.Errorf("invalid exponentialFactor %q: must be a float string", zau.Spec.ExponentialFactor)
+	}
+	if !(factor >= 0) {
+		return fmt.Errorf("invalid exponentialFactor %q: must not be negative", zau.Spec.ExponentialFactor)
+	}
+
+	if zau.Spec.MaxUnavailable == nil {
+		return fmt.Errorf("maxUnavailable is required")
+	}
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(zau.Spec.MaxUnavailable, 100, true)
+	if err != nil {
+		return fmt.Errorf("invalid maxUnavailable %q: %w", zau.Spec.MaxUnavailable.String(), err)
+	}
+	if maxUnavailable <= 0 {
+		return fmt.Errorf("invalid maxUnavailable %q: must be greater than 0", zau.Spec.MaxUnavailable.String())
+	}
+	if zau.Spec.DeletionMethod == opsv1.DeletionMethodEvict && zau.Spec.Workload != nil &&
+		zau.Spec.Workload.Kind == utils.ControllerKindDeployment.Kind {
+		return fmt.Errorf("invalid deletionMethod %s: not supported for Deployments", zau.Spec.DeletionMethod)
+	}
+	if zau.Spec.MaxConcurrentZones < 1 {
+		return fmt.Errorf("invalid maxConcurrentZones %d: must be greater than 0", zau.Spec.MaxConcurrentZones)
+	}
+
+	if zau.Spec.StatefulSetSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(zau.Spec.StatefulSetSelector)
+		if err != nil {
+			return fmt.Errorf("invalid statefulsetSelector: %w", err)
+		}
+		if selector.Empty() {
+			return fmt.Errorf("invalid statefulsetSelector: must not be empty")
+		}
+	}
+
+	for i := range zau.Spec.Steps {
+		stepSize, err := intstr.GetScaledValueFromIntOrPercent(&zau.Spec.Steps[i], 100, true)
+		if err != nil {
+			return fmt.Errorf("invalid step %q: %w", zau.Spec.Steps[i].String(), err)
+		}
+		if stepSize <= 0 {
+			return fmt.Errorf("invalid step %q: must be greater than 0", zau.Spec.Steps[i].String())
+		}
+	}
+	return nil
+}
+
+// targetStatefulSets returns the sorted names of the StatefulSets rolled out by the ZAU, either referenced
+// by name or selected among the given StatefulSets.
+func targetStatefulSets(zau *opsv1.ZoneAwareUpdate, statefulSets []apps.StatefulSet) ([]string, error) {
+	if zau.Spec.Workload != nil {
+		if zau.Spec.Workload.Kind != utils.ControllerKindSS.Kind {
+			return nil, nil
+		}
+		return []string{zau.Spec.Workload.Name}, nil
+	}
+
+	if len(zau.Spec.StatefulSets) == 0 && zau.Spec.StatefulSetSelector == nil {
+		if zau.Spec.StatefulSet == "" {
+			return nil, nil
+		}
+		return []string{zau.Spec.StatefulSet}, nil
+	}
+
+	names := []string{}
+	for _, name := range zau.Spec.StatefulSets {
+		if !utils.ContainsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	if zau.Spec.StatefulSetSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(zau.Spec.StatefulSetSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid statefulsetSelector: %w", err)
+		}
+		for i := range statefulSets {
+			sts := &statefulSets[i]
+			if !selector.Empty() && selector.Matches(labels.Set(sts.Labels)) && !utils.ContainsString(names, sts.Name) {
+				names = append(names, sts.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// statefulSetWarnings warns about target StatefulSets that don't exist or can't be rolled out by the ZAU.
+func statefulSetWarnings(targets []string, statefulSets []apps.StatefulSet) []string {
+	warnings := []string{}
+	for _, name := range targets {
+		var target *apps.StatefulSet
+		for i := range statefulSets {
+			if statefulSets[i].Name == name {
+				target = &statefulSets[i]
+				break
+			}
+		}
+		if target == nil {
+			warnings = append(warnings, fmt.Sprintf("StatefulSet %s not found", name))
+			continue
+		}
+		if target.Spec.UpdateStrategy.Type != apps.OnDeleteStatefulSetStrategyType {
+			warnings = append(warnings, fmt.Sprintf("StatefulSet %s update strategy is %s, the ZoneAwareUpdate requires OnDelete",
+				name, target.Spec.UpdateStrategy.Type))
+		}
+	}
+	return warnings
+}
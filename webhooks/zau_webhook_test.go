@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	opsv1 "github.com/aws/zone-aware-controllers-for-k8s/api/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("ZoneAwareUpdate Validating Webhook", func() {
+	Context("When the ZAU spec is valid", func() {
+		It("Should allow the ZAU", func() {
+			ss := testUtils.CreateStatefulSet(3, "zau-valid")
+			Expect(k8sClient.Create(ctx, newZau("zau-valid", ss.Name, "2.0", intstr.FromString("50%")))).Should(Succeed())
+		})
+	})
+
+	Context("When the exponential factor is invalid", func() {
+		It("Should deny a non-numeric factor", func() {
+			err := k8sClient.Create(ctx, newZau("zau-factor", "zau-factor-ss", "fast", intstr.FromInt(1)))
+			Expect(err).Should(MatchError(ContainSubstring("invalid exponentialFactor")))
+		})
+
+		It("Should deny a negative factor", func() {
+			err := k8sClient.Create(ctx, newZau("zau-negative-factor", "zau-negative-factor-ss", "-1", intstr.FromInt(1)))
+			Expect(err).Should(MatchError(ContainSubstring("must not be negative")))
+		})
+	})
+
+	Context("When the max unavailable is invalid", func() {
+		It("Should deny a zero max unavailable", func() {
+			err := k8sClient.Create(ctx, newZau("zau-zero-unavailable", "zau-zero-unavailable-ss", "2.0", intstr.FromInt(0)))
+			Expect(err).Should(MatchError(ContainSubstring("invalid maxUnavailable")))
+		})
+
+		It("Should deny a malformed percentage", func() {
+			err := k8sClient.Create(ctx, newZau("zau-bad-percent", "zau-bad-percent-ss", "2.0", intstr.FromString("half")))
+			Expect(err).Should(MatchError(ContainSubstring("invalid maxUnavailable")))
+		})
+	})
+
+	Context("When the max concurrent zones is invalid", func() {
+		It("Should deny a negative max concurrent zones", func() {
+			zau := newZau("zau-negative-zones", "zau-negative-zones-ss", "2.0", intstr.FromInt(1))
+			zau.Spec.MaxConcurrentZones = -1
+			Expect(k8sClient.Create(ctx, zau)).Should(MatchError(ContainSubstring("maxConcurrentZones")))
+		})
+	})
+
+	Context("When the max concurrent zones is zero", func() {
+		It("Should deny the ZAU", func() {
+			zau := newZau("zau-zero-zones", "zau-zero-zones-ss", "2.0", intstr.FromInt(1))
+			Expect(validateZauSpec(zau)).Should(MatchError(ContainSubstring("invalid maxConcurrentZones")))
+		})
+	})
+
+	Context("When the deletion method is Evict for a Deployment", func() {
+		It("Should deny the ZAU", func() {
+			zau := newZau("zau-evict-deployment", "", "2.0", intstr.FromInt(1))
+			zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: "zau-evict-deployment"}
+			zau.Spec.DeletionMethod = opsv1.DeletionMethodEvict
+			Expect(k8sClient.Create(ctx, zau)).Should(MatchError(ContainSubstring("invalid deletionMethod")))
+		})
+	})
+
+	Context("When the Deployment doesn't use the Recreate strategy", func() {
+		It("Should deny the ZAU", func() {
+			deployment := testUtils.CreateDeployment(3, "zau-rolling-deployment", "new")
+			deployment.Spec.Strategy = apps.DeploymentStrategy{Type: apps.RollingUpdateDeploymentStrategyType}
+			Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+
+			zau := newZau("zau-rolling-deployment", "", "2.0", intstr.FromInt(1))
+			zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+			Expect(k8sClient.Create(ctx, zau)).Should(MatchError(ContainSubstring("must use the Recreate strategy")))
+		})
+
+		It("Should allow the ZAU with the Recreate strategy", func() {
+			deployment := testUtils.CreateDeployment(3, "zau-recreate-deployment", "new")
+
+			zau := newZau("zau-recreate-deployment", "", "2.0", intstr.FromInt(1))
+			zau.Spec.Workload = &opsv1.WorkloadReference{Kind: "Deployment", Name: deployment.Name}
+			Expect(k8sClient.Create(ctx, zau)).Should(Succeed())
+		})
+	})
+
+	Context("When a step is invalid", func() {
+		It("Should deny a zero step", func() {
+			zau := newZau("zau-zero-step", "zau-zero-step-ss", "2.0", intstr.FromInt(1))
+			zau.Spec.Steps = []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("0%")}
+			Expect(k8sClient.Create(ctx, zau)).Should(MatchError(ContainSubstring("invalid step")))
+		})
+	})
+
+	Context("When the StatefulSet selector is empty", func() {
+		It("Should deny the ZAU", func() {
+			zau := newZau("zau-empty-selector", "", "2.0", intstr.FromInt(1))
+			zau.Spec.StatefulSetSelector = &metav1.LabelSelector{}
+			Expect(k8sClient.Create(ctx, zau)).Should(MatchError(ContainSubstring("must not be empty")))
+		})
+	})
+
+	Context("When another ZAU targets the same StatefulSet", func() {
+		It("Should deny the second ZAU", func() {
+			ss := testUtils.CreateStatefulSet(3, "zau-duplicate")
+			Expect(k8sClient.Create(ctx, newZau("zau-duplicate", ss.Name, "2.0", intstr.FromInt(1)))).Should(Succeed())
+
+			second := newZau("zau-duplicate-2", "", "2.0", intstr.FromInt(1))
+			second.Spec.StatefulSets = []string{ss.Name}
+			Expect(k8sClient.Create(ctx, second)).Should(MatchError(ContainSubstring("already rolled out by ZoneAwareUpdate zau-duplicate")))
+		})
+	})
+
+	Context("When a ZAU sharing its StatefulSet is updated", func() {
+		It("Should allow updates that don't change the spec or the targets", func() {
+			ss := testUtils.CreateStatefulSet(3, "zau-shared")
+			Expect(k8sClient.Create(ctx, newZau("zau-shared", ss.Name, "2.0", intstr.FromInt(1)))).Should(Succeed())
+
+			// Created before the webhook
+			existing := newZau("zau-shared-2", ss.Name, "2.0", intstr.FromInt(1))
+			existing.Spec.MaxConcurrentZones = 1
+			updated := existing.DeepCopy()
+			updated.Finalizers = []string{"zonecontrol.k8s.aws/restore-update-strategy"}
+			Expect(handleZau(admissionv1.Update, updated, existing).Allowed).Should(BeTrue())
+
+			updated.Spec.DryRun = true
+			Expect(handleZau(admissionv1.Update, updated, existing).Allowed).Should(BeTrue())
+
+			Expect(handleZau(admissionv1.Create, existing, nil).Allowed).Should(BeFalse())
+		})
+
+		It("Should allow a ZAU being deleted", func() {
+			existing := newZau("zau-deleted", "zau-deleted-ss", "-1", intstr.FromInt(0))
+			updated := existing.DeepCopy()
+			now := metav1.Now()
+			updated.DeletionTimestamp = &now
+			updated.Spec.DryRun = true
+			Expect(handleZau(admissionv1.Update, updated, existing).Allowed).Should(BeTrue())
+		})
+	})
+
+	Context("When the target StatefulSet can't be rolled out", func() {
+		It("Should warn about missing and non OnDelete StatefulSets", func() {
+			statefulSets := []apps.StatefulSet{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "rolling-ss"},
+					Spec: apps.StatefulSetSpec{
+						UpdateStrategy: apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "ondelete-ss"},
+					Spec: apps.StatefulSetSpec{
+						UpdateStrategy: apps.StatefulSetUpdateStrategy{Type: apps.OnDeleteStatefulSetStrategyType},
+					},
+				},
+			}
+			warnings := statefulSetWarnings([]string{"missing-ss", "ondelete-ss", "rolling-ss"}, statefulSets)
+			Expect(warnings).Should(Equal([]string{
+				"StatefulSet missing-ss not found",
+				"StatefulSet rolling-ss update strategy is RollingUpdate, the ZoneAwareUpdate requires OnDelete",
+			}))
+		})
+	})
+})
+
+// handleZau calls the validating webhook for the ZAU, without going through the API server.
+func handleZau(operation admissionv1.Operation, zau *opsv1.ZoneAwareUpdate, oldZau *opsv1.ZoneAwareUpdate) admission.Response {
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	Expect(err).Should(BeNil())
+	validator := &ZoneAwareUpdateValidator{Client: k8sClient, Logger: ctrl.Log.WithName("zau-webhook-test")}
+	Expect(validator.InjectDecoder(decoder)).Should(Succeed())
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: operation,
+		Name:      zau.Name,
+		Namespace: zau.Namespace,
+		Object:    runtime.RawExtension{Raw: toJSON(zau)},
+	}}
+	if oldZau != nil {
+		req.OldObject = runtime.RawExtension{Raw: toJSON(oldZau)}
+	}
+	return validator.Handle(ctx, req)
+}
+
+func toJSON(obj interface{}) []byte {
+	raw, err := json.Marshal(obj)
+	Expect(err).Should(BeNil())
+	return raw
+}
+
+func newZau(name string, statefulset string, exponentialFactor string, maxUnavailable intstr.IntOrString) *opsv1.ZoneAwareUpdate {
+	return &opsv1.ZoneAwareUpdate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: opsv1.ZoneAwareUpdateSpec{
+			StatefulSet:       statefulset,
+			MaxUnavailable:    &maxUnavailable,
+			ExponentialFactor: exponentialFactor,
+		},
+	}
+}